@@ -0,0 +1,121 @@
+// migrateコマンドはschema_migrationsテーブルを用いてDBスキーマをUp/Down/Status/Version管理するCLI
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/sake-kasu/sake-hack-backend/internal/config"
+	"github.com/sake-kasu/sake-hack-backend/internal/database"
+	"github.com/sake-kasu/sake-hack-backend/internal/database/migrations"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "設定の読み込みに失敗しました: %v\n", err)
+		os.Exit(1)
+	}
+
+	db, err := database.NewPostgresDB(database.PostgresConfig{
+		Host:            cfg.Database.Host,
+		Port:            cfg.Database.Port,
+		Database:        cfg.Database.Database,
+		User:            cfg.Database.User,
+		Password:        cfg.Database.Password,
+		SSLMode:         cfg.Database.SSLMode,
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "データベースへの接続に失敗しました: %v\n", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	migrator, err := migrations.NewMigrator(db, migrations.SQLFiles)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "マイグレーターの初期化に失敗しました: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+
+	if err := run(ctx, migrator, os.Args[1:]); err != nil {
+		if errors.Is(err, migrations.ErrAlreadyLocked) || errors.Is(err, migrations.ErrDirtyState) {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "マイグレーションの実行に失敗しました: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run はサブコマンドをディスパッチする
+func run(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(ctx); err != nil {
+			return err
+		}
+		fmt.Println("マイグレーションを適用しました")
+		return nil
+
+	case "down":
+		if len(args) < 2 {
+			return fmt.Errorf("downにはロールバックする件数を指定してください: migrate down N")
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("ロールバック件数の指定が不正です: %w", err)
+		}
+		if err := migrator.Down(ctx, n); err != nil {
+			return err
+		}
+		fmt.Printf("%d件のマイグレーションをロールバックしました\n", n)
+		return nil
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			state := "未適用"
+			if s.Applied {
+				state = "適用済み"
+			}
+			fmt.Printf("%04d_%s: %s\n", s.Version, s.Name, state)
+		}
+		return nil
+
+	case "version":
+		version, dirty, ok, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("マイグレーション未適用です")
+			return nil
+		}
+		fmt.Printf("version=%d dirty=%t\n", version, dirty)
+		return nil
+
+	default:
+		usage()
+		return fmt.Errorf("未知のサブコマンドです: %s", args[0])
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "使い方: migrate <up|down N|status|version>")
+}
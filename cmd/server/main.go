@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/sake-kasu/sake-hack-backend/internal/config"
 	"github.com/sake-kasu/sake-hack-backend/internal/database"
 	"github.com/sake-kasu/sake-hack-backend/internal/logger"
@@ -15,17 +16,54 @@ import (
 
 func main() {
 	// 設定読み込み(ロガー初期化前に必要)
-	cfg, err := config.Load()
+	// Watcherはconfig.yamlの変更を監視し、再起動不要な項目(ログレベルなど)をホットリロードする
+	cfg, watcher, err := config.LoadWithWatcher()
 	if err != nil {
 		log.Fatalf("設定の読み込みに失敗しました: %v", err)
 	}
 
-	// ロガー初期化
-	if err := logger.Init(cfg.Logging.Level, cfg.Logging.Format); err != nil {
+	// ロガー初期化(stdout/ファイル/Lokiへファンアウト)
+	if err := logger.InitWithConfig(logger.LogConfig{
+		Level:  cfg.Logging.Level,
+		Format: cfg.Logging.Format,
+		File: logger.FileConfig{
+			Enable:     cfg.Logging.File.Enable,
+			Path:       cfg.Logging.File.Path,
+			MaxSizeMB:  cfg.Logging.File.MaxSizeMB,
+			MaxBackups: cfg.Logging.File.MaxBackups,
+			MaxAgeDays: cfg.Logging.File.MaxAgeDays,
+			Compress:   cfg.Logging.File.Compress,
+		},
+		Loki: logger.LokiConfig{
+			Enable:        cfg.Logging.Loki.Enable,
+			Host:          cfg.Logging.Loki.Host,
+			Port:          cfg.Logging.Loki.Port,
+			Job:           cfg.Logging.Loki.Job,
+			Service:       cfg.Logging.Loki.Service,
+			Env:           cfg.Logging.Loki.Env,
+			BatchSize:     cfg.Logging.Loki.BatchSize,
+			BatchInterval: cfg.Logging.Loki.BatchInterval,
+			HTTPTimeout:   cfg.Logging.Loki.HTTPTimeout,
+			MaxRetries:    cfg.Logging.Loki.MaxRetries,
+			DropDir:       cfg.Logging.Loki.DropDir,
+		},
+	}); err != nil {
 		log.Fatalf("ロガーの初期化に失敗しました: %v", err)
 	}
 	defer logger.Sync()
 
+	// ログレベルはプロセス再起動なしでホットリロードする
+	watcher.Subscribe("logging", func(old, new any) {
+		newLogging := new.(config.LoggingConfig)
+		logger.SetLevel(newLogging.Level)
+		logger.Get().Info("ログレベルを変更しました", zap.String("level", newLogging.Level))
+	})
+
+	// Server.Port/Database.Hostなど再起動が必要な項目が変更された場合は警告を出すに留める
+	watcher.OnRestartRequired(func(fields []string) {
+		logger.Get().Warn("再起動が必要な設定が変更されました。反映するにはプロセスを再起動してください", zap.Strings("fields", fields))
+	})
+
 	logger.Get().Info("サーバーを起動します...")
 
 	// PostgreSQL接続
@@ -63,8 +101,30 @@ func main() {
 	}
 	logger.Get().Info("Valkeyへの接続に成功しました")
 
+	// Redis接続(cache.backend=redisの場合のみ)
+	// Redis互換プロトコルのサーバーに対してgo-redisで接続するため、接続先はValkeyと同じ設定を流用する
+	var redisClient *redis.Client
+	if cfg.Cache.Backend == "redis" {
+		redisClient, err = database.NewRedisClient(database.RedisConfig{
+			Host:         cfg.Valkey.Host,
+			Port:         cfg.Valkey.Port,
+			Password:     cfg.Valkey.Password,
+			Database:     cfg.Valkey.Database,
+			PoolSize:     cfg.Valkey.PoolSize,
+			MinIdleConns: cfg.Valkey.MinIdleConns,
+			MaxRetries:   cfg.Valkey.MaxRetries,
+			DialTimeout:  cfg.Valkey.DialTimeout,
+			ReadTimeout:  cfg.Valkey.ReadTimeout,
+			WriteTimeout: cfg.Valkey.WriteTimeout,
+		})
+		if err != nil {
+			logger.Get().Fatal("Redisへの接続に失敗しました", zap.Error(err))
+		}
+		logger.Get().Info("Redisへの接続に成功しました")
+	}
+
 	// サーバー作成
-	srv := server.New(cfg, postgresPool, valkeyClient)
+	srv := server.New(cfg, postgresPool, valkeyClient, redisClient)
 
 	// サーバー起動(Goroutine)
 	go func() {
@@ -0,0 +1,20 @@
+// Package cache はSakeRepository等の読み取りクエリ向けの読み取り透過キャッシュを提供する
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache はタグベース/プレフィックスベースの一括無効化をサポートするキャッシュの抽象
+type Cache interface {
+	// Get はキーに対応する値を取得する。存在しない場合はfound=falseを返す
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	// Set はキーに値をTTL付きで保存し、tagsに紐づけて記録する
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags []string) error
+	// DeleteByTag はtagに紐づく全てのキーを削除する
+	DeleteByTag(ctx context.Context, tag string) error
+	// DeleteByPrefix はprefixで始まる全てのキーを削除する
+	// write usecaseが追加された際に"sake:list:"のようなキー空間ごと無効化できるようにするためのもの
+	DeleteByPrefix(ctx context.Context, prefix string) error
+}
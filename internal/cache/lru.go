@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// lruEntry はLRUCacheが保持する値とその有効期限・タグ
+type lruEntry struct {
+	value     []byte
+	expiresAt time.Time
+	tags      []string
+}
+
+// LRUCache はインプロセスのLRUキャッシュ実装
+// タグ→キー集合の対応は別途mapで保持し、DeleteByTagで引けるようにする
+type LRUCache struct {
+	mu        sync.Mutex
+	entries   *lru.Cache[string, lruEntry]
+	tagToKeys map[string]map[string]struct{}
+}
+
+// NewLRUCache は最大sizeエントリを保持するLRUCacheを作成する
+func NewLRUCache(size int) (*LRUCache, error) {
+	entries, err := lru.New[string, lruEntry](size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LRUCache{
+		entries:   entries,
+		tagToKeys: map[string]map[string]struct{}{},
+	}, nil
+}
+
+// Get はキーに対応する値を取得する。期限切れの場合はmiss扱いとしエントリを破棄する
+func (c *LRUCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		c.removeLocked(key, entry.tags)
+		return nil, false, nil
+	}
+
+	return entry.value, true, nil
+}
+
+// Set はキーに値をTTL付きで保存し、タグインデックスを更新する
+func (c *LRUCache) Set(_ context.Context, key string, value []byte, ttl time.Duration, tags []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries.Add(key, lruEntry{
+		value:     value,
+		expiresAt: time.Now().Add(ttl),
+		tags:      tags,
+	})
+
+	for _, tag := range tags {
+		if c.tagToKeys[tag] == nil {
+			c.tagToKeys[tag] = map[string]struct{}{}
+		}
+		c.tagToKeys[tag][key] = struct{}{}
+	}
+
+	return nil
+}
+
+// DeleteByTag はtagに紐づく全てのキーを削除する
+func (c *LRUCache) DeleteByTag(_ context.Context, tag string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys, ok := c.tagToKeys[tag]
+	if !ok {
+		return nil
+	}
+
+	for key := range keys {
+		c.entries.Remove(key)
+	}
+	delete(c.tagToKeys, tag)
+
+	return nil
+}
+
+// DeleteByPrefix はprefixで始まる全てのキーを削除する
+func (c *LRUCache) DeleteByPrefix(_ context.Context, prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range c.entries.Keys() {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		entry, ok := c.entries.Peek(key)
+		if !ok {
+			continue
+		}
+		c.removeLocked(key, entry.tags)
+	}
+
+	return nil
+}
+
+// removeLocked はキーをエントリとタグインデックスの両方から取り除く(呼び出し側でmu取得済みであること)
+func (c *LRUCache) removeLocked(key string, tags []string) {
+	c.entries.Remove(key)
+	for _, tag := range tags {
+		delete(c.tagToKeys[tag], key)
+	}
+}
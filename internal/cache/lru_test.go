@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCache_SetGet(t *testing.T) {
+	c, err := NewLRUCache(10)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = c.Set(ctx, "key1", []byte("value1"), time.Minute, []string{"sake:*"})
+	require.NoError(t, err)
+
+	value, found, err := c.Get(ctx, "key1")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("value1"), value)
+}
+
+func TestLRUCache_Get_Miss(t *testing.T) {
+	c, err := NewLRUCache(10)
+	require.NoError(t, err)
+
+	_, found, err := c.Get(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestLRUCache_Get_Expired(t *testing.T) {
+	c, err := NewLRUCache(10)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = c.Set(ctx, "key1", []byte("value1"), -time.Second, []string{"sake:*"})
+	require.NoError(t, err)
+
+	_, found, err := c.Get(ctx, "key1")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestLRUCache_DeleteByTag_DropsOnlyAffectedKeys(t *testing.T) {
+	c, err := NewLRUCache(10)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, c.Set(ctx, "key1", []byte("v1"), time.Minute, []string{"sake_type:1", "sake:*"}))
+	require.NoError(t, c.Set(ctx, "key2", []byte("v2"), time.Minute, []string{"sake_type:2", "sake:*"}))
+
+	require.NoError(t, c.DeleteByTag(ctx, "sake_type:1"))
+
+	_, found1, _ := c.Get(ctx, "key1")
+	assert.False(t, found1)
+
+	value2, found2, _ := c.Get(ctx, "key2")
+	assert.True(t, found2)
+	assert.Equal(t, []byte("v2"), value2)
+}
+
+func TestLRUCache_DeleteByPrefix_DropsOnlyMatchingKeys(t *testing.T) {
+	c, err := NewLRUCache(10)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, c.Set(ctx, "sake:list:abc", []byte("v1"), time.Minute, nil))
+	require.NoError(t, c.Set(ctx, "sake:list:def", []byte("v2"), time.Minute, nil))
+	require.NoError(t, c.Set(ctx, "brewery:detail:1", []byte("v3"), time.Minute, nil))
+
+	require.NoError(t, c.DeleteByPrefix(ctx, "sake:list:"))
+
+	_, found1, _ := c.Get(ctx, "sake:list:abc")
+	assert.False(t, found1)
+	_, found2, _ := c.Get(ctx, "sake:list:def")
+	assert.False(t, found2)
+
+	value3, found3, _ := c.Get(ctx, "brewery:detail:1")
+	assert.True(t, found3)
+	assert.Equal(t, []byte("v3"), value3)
+}
+
+func TestLRUCache_DeleteByTag_Unknown(t *testing.T) {
+	c, err := NewLRUCache(10)
+	require.NoError(t, err)
+
+	err = c.DeleteByTag(context.Background(), "unknown")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix はキャッシュキーの名前空間
+const redisKeyPrefix = "sake_cache:"
+
+// redisTagPrefix はタグ→キー集合(SET)の名前空間
+const redisTagPrefix = "sake_cache_tag:"
+
+// RedisCache はgo-redis(Redis互換プロトコル)を用いた分散キャッシュ実装
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache コンストラクタ
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+// Get はキーに対応する値を取得する
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("redisからのキャッシュ取得に失敗しました: %w", err)
+	}
+
+	return value, true, nil
+}
+
+// Set はキーに値をTTL付きで保存し、各タグのSETにキーを追加する
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags []string) error {
+	fullKey := redisKeyPrefix + key
+
+	if err := c.client.Set(ctx, fullKey, value, ttl).Err(); err != nil {
+		return fmt.Errorf("redisへのキャッシュ保存に失敗しました: %w", err)
+	}
+
+	for _, tag := range tags {
+		if err := c.client.SAdd(ctx, redisTagPrefix+tag, fullKey).Err(); err != nil {
+			return fmt.Errorf("redisのタグインデックス更新に失敗しました: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteByPrefix はprefixで始まる全てのキーをSCANで列挙しながら削除する
+// KEYSコマンドはプロダクションでブロッキングの懸念があるためSCANを使う
+func (c *RedisCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	pattern := redisKeyPrefix + prefix + "*"
+	var cursor uint64
+
+	for {
+		keys, nextCursor, err := c.client.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return fmt.Errorf("redisのSCANに失敗しました: %w", err)
+		}
+
+		if len(keys) > 0 {
+			if err := c.client.Del(ctx, keys...).Err(); err != nil {
+				return fmt.Errorf("redisのキャッシュ削除に失敗しました: %w", err)
+			}
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+// DeleteByTag はtagに紐づく全てのキーを削除する
+func (c *RedisCache) DeleteByTag(ctx context.Context, tag string) error {
+	tagKey := redisTagPrefix + tag
+
+	members, err := c.client.SMembers(ctx, tagKey).Result()
+	if err != nil {
+		return fmt.Errorf("redisのタグインデックス取得に失敗しました: %w", err)
+	}
+
+	if len(members) > 0 {
+		if err := c.client.Del(ctx, members...).Err(); err != nil {
+			return fmt.Errorf("redisのキャッシュ削除に失敗しました: %w", err)
+		}
+	}
+
+	if err := c.client.Del(ctx, tagKey).Err(); err != nil {
+		return fmt.Errorf("redisのタグインデックス削除に失敗しました: %w", err)
+	}
+
+	return nil
+}
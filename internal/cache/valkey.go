@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+// valkeyKeyPrefix はキャッシュキーの名前空間
+const valkeyKeyPrefix = "sake_cache:"
+
+// valkeyTagPrefix はタグ→キー集合(SET)の名前空間
+const valkeyTagPrefix = "sake_cache_tag:"
+
+// ValkeyCache はValkeyを用いた分散キャッシュ実装
+type ValkeyCache struct {
+	client valkey.Client
+}
+
+// NewValkeyCache コンストラクタ
+func NewValkeyCache(client valkey.Client) *ValkeyCache {
+	return &ValkeyCache{client: client}
+}
+
+// Get はキーに対応する値を取得する
+func (c *ValkeyCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	resp := c.client.Do(ctx, c.client.B().Get().Key(valkeyKeyPrefix+key).Build())
+
+	if resp.Error() != nil {
+		if valkey.IsValkeyNil(resp.Error()) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("valkeyからのキャッシュ取得に失敗しました: %w", resp.Error())
+	}
+
+	value, err := resp.AsBytes()
+	if err != nil {
+		return nil, false, fmt.Errorf("valkeyキャッシュ値のデコードに失敗しました: %w", err)
+	}
+
+	return value, true, nil
+}
+
+// Set はキーに値をTTL付きで保存し、各タグのSETにキーを追加する
+func (c *ValkeyCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags []string) error {
+	fullKey := valkeyKeyPrefix + key
+
+	if err := c.client.Do(ctx, c.client.B().Set().Key(fullKey).Value(string(value)).Ex(ttl).Build()).Error(); err != nil {
+		return fmt.Errorf("valkeyへのキャッシュ保存に失敗しました: %w", err)
+	}
+
+	for _, tag := range tags {
+		if err := c.client.Do(ctx, c.client.B().Sadd().Key(valkeyTagPrefix+tag).Member(fullKey).Build()).Error(); err != nil {
+			return fmt.Errorf("valkeyのタグインデックス更新に失敗しました: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteByPrefix はprefixで始まる全てのキーをSCANで列挙しながら削除する
+// KEYSコマンドはプロダクションでブロッキングの懸念があるためSCANを使う
+func (c *ValkeyCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	pattern := valkeyKeyPrefix + prefix + "*"
+	cursor := uint64(0)
+
+	for {
+		entry, err := c.client.Do(ctx, c.client.B().Scan().Cursor(cursor).Match(pattern).Count(100).Build()).AsScanEntry()
+		if err != nil {
+			return fmt.Errorf("valkeyのSCANに失敗しました: %w", err)
+		}
+
+		if len(entry.Elements) > 0 {
+			if err := c.client.Do(ctx, c.client.B().Del().Key(entry.Elements...).Build()).Error(); err != nil {
+				return fmt.Errorf("valkeyのキャッシュ削除に失敗しました: %w", err)
+			}
+		}
+
+		cursor = entry.Cursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+// DeleteByTag はtagに紐づく全てのキーを削除する
+func (c *ValkeyCache) DeleteByTag(ctx context.Context, tag string) error {
+	tagKey := valkeyTagPrefix + tag
+
+	members, err := c.client.Do(ctx, c.client.B().Smembers().Key(tagKey).Build()).AsStrSlice()
+	if err != nil {
+		return fmt.Errorf("valkeyのタグインデックス取得に失敗しました: %w", err)
+	}
+
+	if len(members) > 0 {
+		if err := c.client.Do(ctx, c.client.B().Del().Key(members...).Build()).Error(); err != nil {
+			return fmt.Errorf("valkeyのキャッシュ削除に失敗しました: %w", err)
+		}
+	}
+
+	if err := c.client.Do(ctx, c.client.B().Del().Key(tagKey).Build()).Error(); err != nil {
+		return fmt.Errorf("valkeyのタグインデックス削除に失敗しました: %w", err)
+	}
+
+	return nil
+}
@@ -1,50 +1,64 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
+	_ "github.com/spf13/viper/remote" // Consul/etcd3リモートプロバイダを副作用で登録する
 )
 
+// defaultJWTSecret はローカル開発用のデフォルトJWT秘密鍵。本番相当環境ではValidateがこの値を拒否する
+const defaultJWTSecret = "your-secret-key-change-me-in-production"
+
 // Config はアプリケーション全体の設定
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Valkey   ValkeyConfig   `mapstructure:"valkey"`
-	JWT      JWTConfig      `mapstructure:"jwt"`
-	CORS     CORSConfig     `mapstructure:"cors"`
-	Logging  LoggingConfig  `mapstructure:"logging"`
+	Server        ServerConfig        `mapstructure:"server"`
+	Database      DatabaseConfig      `mapstructure:"database"`
+	Valkey        ValkeyConfig        `mapstructure:"valkey"`
+	JWT           JWTConfig           `mapstructure:"jwt"`
+	CORS          CORSConfig          `mapstructure:"cors"`
+	Logging       LoggingConfig       `mapstructure:"logging"`
+	Cache         CacheConfig         `mapstructure:"cache"`
+	RateLimit     RateLimitConfig     `mapstructure:"rateLimit"`
+	RequestParser RequestParserConfig `mapstructure:"requestParser"`
+	Remote        RemoteConfig        `mapstructure:"remote"`
 }
 
 // ServerConfig はサーバー設定
+// Portはリスナーを開き直さない限り反映できないためreload:"restart"を付与する
 type ServerConfig struct {
-	Port                    int           `mapstructure:"port"`
+	Port                    int           `mapstructure:"port" reload:"restart"`
 	Mode                    string        `mapstructure:"mode"`
 	GracefulShutdownTimeout time.Duration `mapstructure:"gracefulShutdownTimeout"`
 }
 
 // DatabaseConfig はPostgreSQL設定
+// 接続プールは起動時に一度だけ確立するため、接続先を変える項目はすべてreload:"restart"
 type DatabaseConfig struct {
-	Host            string        `mapstructure:"host"`
-	Port            int           `mapstructure:"port"`
-	Database        string        `mapstructure:"database"`
-	User            string        `mapstructure:"user"`
-	Password        string        `mapstructure:"password"`
-	SSLMode         string        `mapstructure:"sslmode"`
+	Host            string        `mapstructure:"host" reload:"restart"`
+	Port            int           `mapstructure:"port" reload:"restart"`
+	Database        string        `mapstructure:"database" reload:"restart"`
+	User            string        `mapstructure:"user" reload:"restart"`
+	Password        string        `mapstructure:"password" reload:"restart" secret:"true"`
+	SSLMode         string        `mapstructure:"sslmode" reload:"restart"`
 	MaxOpenConns    int           `mapstructure:"maxOpenConns"`
 	MaxIdleConns    int           `mapstructure:"maxIdleConns"`
 	ConnMaxLifetime time.Duration `mapstructure:"connMaxLifetime"`
 }
 
 // ValkeyConfig はValkey設定
+// 接続先(Host/Port/Password/Database)は再接続が必要なためreload:"restart"、
+// プールサイジングはクライアント側で動的に調整可能なためホットリロード対象とする
 type ValkeyConfig struct {
-	Host         string        `mapstructure:"host"`
-	Port         int           `mapstructure:"port"`
-	Password     string        `mapstructure:"password"`
-	Database     int           `mapstructure:"database"`
+	Host         string        `mapstructure:"host" reload:"restart"`
+	Port         int           `mapstructure:"port" reload:"restart"`
+	Password     string        `mapstructure:"password" reload:"restart" secret:"true"`
+	Database     int           `mapstructure:"database" reload:"restart"`
 	PoolSize     int           `mapstructure:"poolSize"`
 	MinIdleConns int           `mapstructure:"minIdleConns"`
 	MaxRetries   int           `mapstructure:"maxRetries"`
@@ -55,7 +69,7 @@ type ValkeyConfig struct {
 
 // JWTConfig はJWT設定
 type JWTConfig struct {
-	Secret       string `mapstructure:"secret"`
+	Secret       string `mapstructure:"secret" secret:"true"`
 	Expiration   int    `mapstructure:"expiration"`
 	CookieSecure bool   `mapstructure:"cookieSecure"`
 	CookieName   string `mapstructure:"cookieName"`
@@ -63,9 +77,12 @@ type JWTConfig struct {
 	CookieDomain string `mapstructure:"cookieDomain"`
 }
 
-// CORSConfig はCORS設定
+// CORSConfig はCORS設定。
+// config.<ENV>.yamlがconfig.yamlへ上書きマージされる際、スライスフィールドはデフォルトで
+// "replace"(上書き)される。mergeStrategy:"append"が付与されたフィールドのみ、
+// ベースの値に環境別オーバーレイの値を連結する("append")
 type CORSConfig struct {
-	AllowedOrigins   []string `mapstructure:"allowedOrigins"`
+	AllowedOrigins   []string `mapstructure:"allowedOrigins" mergeStrategy:"append"`
 	AllowedMethods   []string `mapstructure:"allowedMethods"`
 	AllowedHeaders   []string `mapstructure:"allowedHeaders"`
 	ExposedHeaders   []string `mapstructure:"exposedHeaders"`
@@ -75,18 +92,87 @@ type CORSConfig struct {
 
 // LoggingConfig はロギング設定
 type LoggingConfig struct {
-	Level  string `mapstructure:"level"`
-	Format string `mapstructure:"format"`
+	Level  string     `mapstructure:"level"`
+	Format string     `mapstructure:"format"`
+	File   FileConfig `mapstructure:"file"`
+	Loki   LokiConfig `mapstructure:"loki"`
 }
 
-// Load は設定を読み込む
-func Load() (*Config, error) {
-	v := viper.New()
+// FileConfig はローテーション付きファイル出力の設定
+type FileConfig struct {
+	Enable     bool   `mapstructure:"enable"`
+	Path       string `mapstructure:"path"`
+	MaxSizeMB  int    `mapstructure:"maxSizeMB"`
+	MaxBackups int    `mapstructure:"maxBackups"`
+	MaxAgeDays int    `mapstructure:"maxAgeDays"`
+	Compress   bool   `mapstructure:"compress"`
+}
+
+// LokiConfig はGrafana Lokiへのログ送信設定
+type LokiConfig struct {
+	Enable        bool          `mapstructure:"enable"`
+	Host          string        `mapstructure:"host"`
+	Port          int           `mapstructure:"port"`
+	Job           string        `mapstructure:"job"`
+	Service       string        `mapstructure:"service"`
+	Env           string        `mapstructure:"env"`
+	BatchSize     int           `mapstructure:"batchSize"`
+	BatchInterval time.Duration `mapstructure:"batchInterval"`
+	HTTPTimeout   time.Duration `mapstructure:"httpTimeout"`
+	MaxRetries    int           `mapstructure:"maxRetries"`
+	DropDir       string        `mapstructure:"dropDir"`
+}
+
+// CacheConfig はSakeRepository読み取りキャッシュの設定
+type CacheConfig struct {
+	// Backend は "lru"(インプロセス)、"valkey"(分散)、"redis"(go-redis経由の分散)のいずれか
+	Backend string        `mapstructure:"backend"`
+	TTL     time.Duration `mapstructure:"ttl"`
+	LRUSize int           `mapstructure:"lruSize"`
+}
+
+// RateLimitConfig はValkeyバックエンドの分散レートリミッターの設定
+type RateLimitConfig struct {
+	Enable bool          `mapstructure:"enable"`
+	Limit  int           `mapstructure:"limit"`
+	Window time.Duration `mapstructure:"window"`
+}
+
+// RequestParserConfig はContent-Typeからrequestparser.Parserを選択するためのフォールバックテーブル
+type RequestParserConfig struct {
+	// Default は該当するContent-Typeが見つからない場合に使うparser名("json" または "form")
+	Default string `mapstructure:"default"`
+	// ContentTypeMap はContent-Type(メディアタイプ)からparser名へのマッピング
+	ContentTypeMap map[string]string `mapstructure:"contentTypeMap"`
+}
+
+// RemoteConfig はConsul/etcd3などリモート設定プロバイダからの設定取得に関する設定。
+// Provider/Endpoint/Path/SecretKeyring自体はCONFIG_REMOTE_*環境変数から取得する
+// (Config本体をUnmarshalする前に接続先を決める必要があるため)。この構造体はUnmarshal後に
+// 実際に使われた値を保持し、ログ出力や診断に利用する
+type RemoteConfig struct {
+	// Provider は "consul" または "etcd3"
+	Provider      string `mapstructure:"provider"`
+	Endpoint      string `mapstructure:"endpoint"`
+	Path          string `mapstructure:"path"`
+	SecretKeyring string `mapstructure:"secretKeyring" secret:"true"`
+}
+
+// buildViper はLoad/LoadWithWatcherで共有するviper初期化ロジック。
+// 戻り値のusingRemoteはリモート設定プロバイダ(Consul/etcd3)を使用したかどうかを表す
+func buildViper() (v *viper.Viper, usingRemote bool, err error) {
+	v = viper.New()
 
 	// 環境変数の優先順位を設定
 	v.AutomaticEnv()
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
+	// LokiはLOG_LOKI_*という慣例的な環境変数名を使うため個別にバインドする
+	_ = v.BindEnv("logging.loki.enable", "LOG_LOKI_ENABLE")
+	_ = v.BindEnv("logging.loki.host", "LOG_LOKI_HOST")
+	_ = v.BindEnv("logging.loki.port", "LOG_LOKI_PORT")
+	_ = v.BindEnv("logging.loki.job", "LOG_LOKI_JOB")
+
 	// デフォルト値を設定
 	setDefaults(v)
 
@@ -96,23 +182,174 @@ func Load() (*Config, error) {
 		env = "local"
 	}
 
-	// ローカル環境の場合はYAMLファイルを読み込む
-	if env == "local" {
-		v.SetConfigName("config")
-		v.SetConfigType("yaml")
-		v.AddConfigPath("./config")
-		v.AddConfigPath("../config")
-		v.AddConfigPath("../../config")
-
-		if err := v.ReadInConfig(); err != nil {
-			// ファイルが存在しない場合は警告を出すが、エラーにはしない
-			fmt.Printf("⚠️  設定ファイルが見つかりません: %v\n", err)
-			fmt.Println("デフォルト値と環境変数を使用します")
-		} else {
-			fmt.Printf("✅ 設定ファイルを読み込みました: %s\n", v.ConfigFileUsed())
+	// ベースのconfig.yamlを読み込み、続けてconfig.<ENV>.yamlがあればマージする
+	loadConfigFiles(v, env)
+
+	if env != "local" {
+		fmt.Printf("✅ 環境: %s\n", env)
+
+		usingRemote, err = configureRemoteProvider(v)
+		if err != nil {
+			return nil, false, err
 		}
+	}
+
+	return v, usingRemote, nil
+}
+
+// corsAppendFields はCORSConfigのうちmergeStrategy:"append"が付与されたフィールドの
+// mapstructureキー(cors.プレフィックス付き)
+var corsAppendFields = collectMergeAppendKeys("cors", CORSConfig{})
+
+// collectMergeAppendKeys はstructTypeのフィールドからmergeStrategy:"append"が付与されたものを
+// section.フィールド名 の形式で列挙する
+func collectMergeAppendKeys(section string, structType interface{}) []string {
+	t := reflect.TypeOf(structType)
+
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("mergeStrategy") != "append" {
+			continue
+		}
+		name := field.Tag.Get("mapstructure")
+		if name == "" {
+			name = field.Name
+		}
+		keys = append(keys, section+"."+name)
+	}
+	return keys
+}
+
+// loadConfigFiles はconfig.yaml(ベース)を読み込み、config.<ENV>.yaml(環境別オーバーレイ)が
+// あればviper.MergeInConfigで重ね合わせる。どちらも見つからない場合は警告のみでエラーにしない。
+// スライスフィールドはMergeInConfigの既定動作に従い上書き(replace)されるため、
+// mergeStrategy:"append"が付与されたフィールドのみベース値とオーバーレイ値を連結し直す
+func loadConfigFiles(v *viper.Viper, env string) {
+	v.SetConfigType("yaml")
+	v.AddConfigPath("./config")
+	v.AddConfigPath("../config")
+	v.AddConfigPath("../../config")
+
+	v.SetConfigName("config")
+	if err := v.ReadInConfig(); err != nil {
+		// ファイルが存在しない場合は警告を出すが、エラーにはしない
+		fmt.Printf("⚠️  ベース設定ファイルが見つかりません: %v\n", err)
+		fmt.Println("デフォルト値と環境変数を使用します")
 	} else {
-		fmt.Printf("✅ 環境: %s (環境変数を使用)\n", env)
+		fmt.Printf("✅ ベース設定ファイルを読み込みました: %s\n", v.ConfigFileUsed())
+	}
+
+	// append対象フィールドはオーバーレイでの上書き前にベースの値を控えておく
+	baseAppendValues := make(map[string][]string, len(corsAppendFields))
+	for _, key := range corsAppendFields {
+		baseAppendValues[key] = v.GetStringSlice(key)
+	}
+
+	overlayName := "config." + env
+	overlayProbe := viper.New()
+	overlayProbe.SetConfigType("yaml")
+	overlayProbe.AddConfigPath("./config")
+	overlayProbe.AddConfigPath("../config")
+	overlayProbe.AddConfigPath("../../config")
+	overlayProbe.SetConfigName(overlayName)
+	overlayFound := overlayProbe.ReadInConfig() == nil
+
+	v.SetConfigName(overlayName)
+	if err := v.MergeInConfig(); err != nil {
+		var notFoundErr viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFoundErr) {
+			fmt.Printf("⚠️  環境別設定ファイル(%s)の読み込みに失敗しました: %v\n", overlayName, err)
+		}
+	} else {
+		fmt.Printf("✅ 環境別設定ファイルをマージしました: %s\n", v.ConfigFileUsed())
+	}
+
+	// viper.WatchConfigは直近に設定したファイル名で監視対象を探すため、監視対象をベースのconfig.yamlに戻す
+	v.SetConfigName("config")
+
+	if !overlayFound {
+		return
+	}
+
+	// viper.Setは最優先レイヤー(AutomaticEnvより上)に書き込まれてしまい、
+	// CORS_ALLOWEDORIGINS等の明示的な環境変数をappendフィールドの結合結果が覆い隠してしまう。
+	// それを避けるため、結合結果はconfigレイヤー(env変数より下位)へMergeConfigMapで差し戻す
+	mergedAppend := map[string]interface{}{}
+	for _, key := range corsAppendFields {
+		if !overlayProbe.IsSet(key) {
+			continue
+		}
+		merged := append(append([]string{}, baseAppendValues[key]...), overlayProbe.GetStringSlice(key)...)
+		setNestedKey(mergedAppend, key, merged)
+	}
+	if len(mergedAppend) > 0 {
+		if err := v.MergeConfigMap(mergedAppend); err != nil {
+			fmt.Printf("⚠️  appendフィールドのマージに失敗しました: %v\n", err)
+		}
+	}
+}
+
+// setNestedKey は "section.field" 形式のドット区切りキーに対応するネストしたmapへvalueを設定する
+func setNestedKey(m map[string]interface{}, dottedKey string, value interface{}) {
+	parts := strings.Split(dottedKey, ".")
+	cur := m
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := cur[part].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			cur[part] = next
+		}
+		cur = next
+	}
+	cur[parts[len(parts)-1]] = value
+}
+
+// configureRemoteProvider はCONFIG_REMOTE_PROVIDER環境変数が設定されている場合に、
+// Viperへリモート設定プロバイダ(Consul/etcd3)を登録してReadRemoteConfigで初回読み込みを行う。
+// 優先順位は「明示的な環境変数 > リモートKV > デフォルト値」のまま変わらない
+// (viper.AutomaticEnvが常にリモート/ファイル由来の値より優先されるため)
+func configureRemoteProvider(v *viper.Viper) (usingRemote bool, err error) {
+	provider := os.Getenv("CONFIG_REMOTE_PROVIDER")
+	if provider == "" {
+		return false, nil
+	}
+
+	endpoint := os.Getenv("CONFIG_REMOTE_ENDPOINT")
+	path := os.Getenv("CONFIG_REMOTE_PATH")
+	secretKeyring := os.Getenv("CONFIG_REMOTE_SECRET_KEYRING")
+
+	v.SetConfigType("yaml")
+
+	if secretKeyring != "" {
+		if err := v.AddSecureRemoteProvider(provider, endpoint, path, secretKeyring); err != nil {
+			return false, fmt.Errorf("リモート設定プロバイダ(%s)の登録に失敗しました: %w", provider, err)
+		}
+	} else {
+		if err := v.AddRemoteProvider(provider, endpoint, path); err != nil {
+			return false, fmt.Errorf("リモート設定プロバイダ(%s)の登録に失敗しました: %w", provider, err)
+		}
+	}
+
+	if err := v.ReadRemoteConfig(); err != nil {
+		return false, fmt.Errorf("リモート設定(provider=%s, endpoint=%s, path=%s)の読み込みに失敗しました: %w", provider, endpoint, path, err)
+	}
+
+	// Config.Remoteへ反映し、ログ出力や診断で実際に使われた接続先を参照できるようにする
+	v.Set("remote.provider", provider)
+	v.Set("remote.endpoint", endpoint)
+	v.Set("remote.path", path)
+	v.Set("remote.secretKeyring", secretKeyring)
+
+	fmt.Printf("✅ リモート設定を読み込みました: provider=%s endpoint=%s path=%s\n", provider, endpoint, path)
+	return true, nil
+}
+
+// Load は設定を読み込む
+func Load() (*Config, error) {
+	v, _, err := buildViper()
+	if err != nil {
+		return nil, err
 	}
 
 	var config Config
@@ -120,9 +357,48 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("設定のアンマーシャルに失敗しました: %w", err)
 	}
 
+	if err := resolveSecrets(&config, defaultResolvers()); err != nil {
+		return nil, fmt.Errorf("シークレットの解決に失敗しました: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &config, nil
 }
 
+// LoadWithWatcher は設定を読み込み、あわせてファイル変更を監視するWatcherを起動する
+// Watcher経由でSubscribeしたコンポーネントはプロセス再起動なしに設定変更へ追従できる
+func LoadWithWatcher() (*Config, *Watcher, error) {
+	v, usingRemote, err := buildViper()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var config Config
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, nil, fmt.Errorf("設定のアンマーシャルに失敗しました: %w", err)
+	}
+
+	if err := resolveSecrets(&config, defaultResolvers()); err != nil {
+		return nil, nil, fmt.Errorf("シークレットの解決に失敗しました: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	watcher := newWatcher(v, &config)
+	if usingRemote {
+		startRemoteWatch(v, watcher)
+	} else {
+		watcher.start()
+	}
+
+	return &config, watcher, nil
+}
+
 // setDefaults はデフォルト値を設定する
 func setDefaults(v *viper.Viper) {
 	// Server
@@ -154,7 +430,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("valkey.writeTimeout", 3*time.Second)
 
 	// JWT
-	v.SetDefault("jwt.secret", "your-secret-key-change-me-in-production")
+	v.SetDefault("jwt.secret", defaultJWTSecret)
 	v.SetDefault("jwt.expiration", 86400)
 	v.SetDefault("jwt.cookieSecure", false)
 	v.SetDefault("jwt.cookieName", "sake_hack_token")
@@ -172,4 +448,48 @@ func setDefaults(v *viper.Viper) {
 	// Logging
 	v.SetDefault("logging.level", "debug")
 	v.SetDefault("logging.format", "console")
+
+	// Logging: ファイル出力
+	v.SetDefault("logging.file.enable", false)
+	v.SetDefault("logging.file.path", "./logs/app.log")
+	v.SetDefault("logging.file.maxSizeMB", 100)
+	v.SetDefault("logging.file.maxBackups", 5)
+	v.SetDefault("logging.file.maxAgeDays", 30)
+	v.SetDefault("logging.file.compress", true)
+
+	// Logging: Loki送信
+	v.SetDefault("logging.loki.enable", false)
+	v.SetDefault("logging.loki.host", "localhost")
+	v.SetDefault("logging.loki.port", 3100)
+	v.SetDefault("logging.loki.job", "sake-hack-backend")
+	v.SetDefault("logging.loki.service", "sake-hack-backend")
+	v.SetDefault("logging.loki.env", "local")
+	v.SetDefault("logging.loki.batchSize", 100)
+	v.SetDefault("logging.loki.batchInterval", 2*time.Second)
+	v.SetDefault("logging.loki.httpTimeout", 5*time.Second)
+	v.SetDefault("logging.loki.maxRetries", 3)
+	v.SetDefault("logging.loki.dropDir", "./logs/loki-dropped")
+
+	// Cache
+	v.SetDefault("cache.backend", "lru")
+	v.SetDefault("cache.ttl", 5*time.Minute)
+	v.SetDefault("cache.lruSize", 1000)
+
+	// RateLimit
+	v.SetDefault("rateLimit.enable", true)
+	v.SetDefault("rateLimit.limit", 100)
+	v.SetDefault("rateLimit.window", time.Minute)
+
+	// RequestParser
+	v.SetDefault("requestParser.default", "json")
+	v.SetDefault("requestParser.contentTypeMap", map[string]string{
+		"application/json":                  "json",
+		"application/x-www-form-urlencoded": "form",
+	})
+
+	// Remote (Consul/etcd3) - 実際の接続先はCONFIG_REMOTE_*環境変数から決まる
+	v.SetDefault("remote.provider", "")
+	v.SetDefault("remote.endpoint", "")
+	v.SetDefault("remote.path", "")
+	v.SetDefault("remote.secretKeyring", "")
 }
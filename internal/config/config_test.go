@@ -49,7 +49,7 @@ valkey:
   writeTimeout: 5s
 
 jwt:
-  secret: "test-secret"
+  secret: "test-secret-that-is-long-enough-32b"
   expiration: 3600
   cookieSecure: true
   cookieName: "test_token"
@@ -121,7 +121,7 @@ logging:
 	assert.Equal(t, 5*time.Second, cfg.Valkey.ReadTimeout)
 	assert.Equal(t, 5*time.Second, cfg.Valkey.WriteTimeout)
 
-	assert.Equal(t, "test-secret", cfg.JWT.Secret)
+	assert.Equal(t, "test-secret-that-is-long-enough-32b", cfg.JWT.Secret)
 	assert.Equal(t, 3600, cfg.JWT.Expiration)
 	assert.True(t, cfg.JWT.CookieSecure)
 	assert.Equal(t, "test_token", cfg.JWT.CookieName)
@@ -177,6 +177,7 @@ func TestLoad_Production(t *testing.T) {
 	t.Setenv("DATABASE_HOST", "prod-db")
 	t.Setenv("DATABASE_PORT", "5432")
 	t.Setenv("VALKEY_HOST", "prod-valkey")
+	t.Setenv("JWT_SECRET", "production-jwt-secret-that-is-long-enough")
 
 	// テスト実行
 	cfg, err := Load()
@@ -191,6 +192,145 @@ func TestLoad_Production(t *testing.T) {
 	assert.Equal(t, "prod-valkey", cfg.Valkey.Host)
 }
 
+// TestLoad_ProductionRejectsDefaultJWTSecret は本番相当環境でJWT秘密鍵がデフォルト値のままの場合に
+// Loadがバリデーションエラーを返すことを確認するテスト
+func TestLoad_ProductionRejectsDefaultJWTSecret(t *testing.T) {
+	t.Setenv("ENV", "production")
+	t.Setenv("SERVER_PORT", "3000")
+	t.Setenv("DATABASE_HOST", "prod-db")
+	t.Setenv("VALKEY_HOST", "prod-valkey")
+	// JWT_SECRETを設定しないため、デフォルト値のままバリデーションされる
+
+	cfg, err := Load()
+	assert.Error(t, err)
+	assert.Nil(t, cfg)
+
+	var validationErrs ValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	found := false
+	for _, e := range validationErrs {
+		if e.Field == "jwt.secret" && e.Message == "本番相当環境ではデフォルト値のままにできません" {
+			found = true
+		}
+	}
+	assert.True(t, found, "デフォルトJWT秘密鍵を拒否するバリデーションエラーが含まれているべき")
+}
+
+// TestLoad_OverlayMerges はconfig.yaml(ベース)とconfig.<ENV>.yaml(環境別オーバーレイ)の
+// マージ挙動を確認するテスト。スカラー値はオーバーレイが勝ち、
+// mergeStrategy:"append"が付与されたCORS.AllowedOriginsはベース値に連結される
+func TestLoad_OverlayMerges(t *testing.T) {
+	tmpDir := t.TempDir()
+	configDir := filepath.Join(tmpDir, "config")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+
+	baseContent := `
+server:
+  port: 8080
+  mode: debug
+
+database:
+  host: base-db
+  sslmode: disable
+
+cors:
+  allowedOrigins:
+    - "http://localhost:3000"
+  allowedMethods:
+    - "GET"
+    - "POST"
+
+jwt:
+  secret: "base-jwt-secret-that-is-long-enough-ok"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(baseContent), 0644))
+
+	overlayContent := `
+server:
+  mode: release
+
+database:
+  host: staging-db
+
+cors:
+  allowedOrigins:
+    - "https://staging.example.com"
+  allowedMethods:
+    - "DELETE"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.staging.yaml"), []byte(overlayContent), 0644))
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.Chdir(originalWd))
+	}()
+	require.NoError(t, os.Chdir(tmpDir))
+
+	t.Setenv("ENV", "staging")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	// スカラーはオーバーレイが勝つ
+	assert.Equal(t, "release", cfg.Server.Mode)
+	assert.Equal(t, "staging-db", cfg.Database.Host)
+	// オーバーレイで触れていない値はベースのまま
+	assert.Equal(t, 8080, cfg.Server.Port)
+	assert.Equal(t, "disable", cfg.Database.SSLMode)
+
+	// mergeStrategy:"append"のフィールドはベース+オーバーレイが連結される
+	assert.Equal(t, []string{"http://localhost:3000", "https://staging.example.com"}, cfg.CORS.AllowedOrigins)
+	// タグなしのフィールドはオーバーレイでの指定がそのまま上書き(replace)される
+	assert.Equal(t, []string{"DELETE"}, cfg.CORS.AllowedMethods)
+}
+
+// TestLoad_OverlayMerge_EnvVarWinsOverAppend はmergeStrategy:"append"のフィールドについても、
+// 明示的な環境変数がベース+オーバーレイの連結結果より優先されることを確認する
+func TestLoad_OverlayMerge_EnvVarWinsOverAppend(t *testing.T) {
+	tmpDir := t.TempDir()
+	configDir := filepath.Join(tmpDir, "config")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+
+	baseContent := `
+server:
+  port: 8080
+
+cors:
+  allowedOrigins:
+    - "http://localhost:3000"
+
+jwt:
+  secret: "base-jwt-secret-that-is-long-enough-ok"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(baseContent), 0644))
+
+	overlayContent := `
+cors:
+  allowedOrigins:
+    - "https://staging.example.com"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.staging.yaml"), []byte(overlayContent), 0644))
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.Chdir(originalWd))
+	}()
+	require.NoError(t, os.Chdir(tmpDir))
+
+	t.Setenv("ENV", "staging")
+	t.Setenv("CORS_ALLOWEDORIGINS", "https://env.example.com")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	// 明示的な環境変数はbase+overlayの連結結果より優先されるべき
+	assert.Equal(t, []string{"https://env.example.com"}, cfg.CORS.AllowedOrigins)
+}
+
 // TestLoad_EnvironmentVariableOverride は環境変数が設定ファイルを上書きするテスト
 func TestLoad_EnvironmentVariableOverride(t *testing.T) {
 	// テスト用の一時ディレクトリを作成
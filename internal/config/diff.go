@@ -0,0 +1,59 @@
+package config
+
+import "reflect"
+
+// fieldChange はConfig構造体を再帰的に比較して見つかった1フィールド分の変更
+type fieldChange struct {
+	path     string // ドット区切りのフィールドパス(例: "database.host")
+	topLevel string // Configのトップレベルセクション名(例: "database")
+	restart  bool   // reload:"restart"が付与されているか
+	secret   bool   // secret:"true"が付与されているか(ログに値を出さない)
+	oldValue any
+	newValue any
+}
+
+// diffStruct はoldValue/newValue(同じ構造体型)を再帰的に比較し、変更のあったリーフフィールドを列挙する
+// prefixは呼び出し時点までのドット区切りパス。トップレベル呼び出しではprefix=""を渡す
+func diffStruct(prefix string, oldValue, newValue reflect.Value, topLevel ...string) []fieldChange {
+	var changes []fieldChange
+
+	t := oldValue.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		oldField := oldValue.Field(i)
+		newField := newValue.Field(i)
+
+		name := field.Tag.Get("mapstructure")
+		if name == "" {
+			name = field.Name
+		}
+
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		top := name
+		if len(topLevel) > 0 {
+			top = topLevel[0]
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			changes = append(changes, diffStruct(path, oldField, newField, top)...)
+			continue
+		}
+
+		if !reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+			changes = append(changes, fieldChange{
+				path:     path,
+				topLevel: top,
+				restart:  field.Tag.Get("reload") == "restart",
+				secret:   field.Tag.Get("secret") == "true",
+				oldValue: oldField.Interface(),
+				newValue: newField.Interface(),
+			})
+		}
+	}
+
+	return changes
+}
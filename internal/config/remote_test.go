@@ -0,0 +1,80 @@
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureRemoteProvider_NoProviderEnvVar(t *testing.T) {
+	t.Setenv("CONFIG_REMOTE_PROVIDER", "")
+
+	usingRemote, err := configureRemoteProvider(viper.New())
+	require.NoError(t, err)
+	assert.False(t, usingRemote)
+}
+
+func TestConfigureRemoteProvider_UnknownProvider_ReturnsError(t *testing.T) {
+	t.Setenv("CONFIG_REMOTE_PROVIDER", "not-a-real-provider")
+	t.Setenv("CONFIG_REMOTE_ENDPOINT", "http://127.0.0.1:1")
+	t.Setenv("CONFIG_REMOTE_PATH", "sake-hack/config")
+
+	_, err := configureRemoteProvider(viper.New())
+	assert.Error(t, err)
+}
+
+// fakeConsulKVServer はConsulのKV HTTP API(GET /v1/kv/<path>)を模したフェイクサーバー。
+// 実際のAPIと同じく、bodyをValue(JSON上はbase64)に持つKVPairのJSON配列を返す
+func fakeConsulKVServer(t *testing.T, path string, body []byte) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := "/v1/kv/" + path
+		if r.URL.Path != expected {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"Key": path, "Value": body},
+		})
+	}))
+}
+
+// TestConfigureRemoteProvider_ConsulKV は、フェイクのConsul KVサーバーからYAML設定を取得し、
+// Load/LoadWithWatcherと同じ優先順位(明示的な環境変数 > リモートKV > デフォルト値)が
+// 保たれることを確認する統合テスト相当のケース
+func TestConfigureRemoteProvider_ConsulKV(t *testing.T) {
+	remoteYAML := []byte(`
+server:
+  port: 9100
+database:
+  host: remote-db
+  sslmode: require
+jwt:
+  secret: remote-jwt-secret-that-is-long-enough
+`)
+
+	server := fakeConsulKVServer(t, "sake-hack/config", remoteYAML)
+	defer server.Close()
+
+	t.Setenv("ENV", "staging")
+	t.Setenv("CONFIG_REMOTE_PROVIDER", "consul")
+	t.Setenv("CONFIG_REMOTE_ENDPOINT", server.URL)
+	t.Setenv("CONFIG_REMOTE_PATH", "sake-hack/config")
+	// 明示的な環境変数はリモートKVより優先されるべき
+	t.Setenv("SERVER_PORT", "9200")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, 9200, cfg.Server.Port, "SERVER_PORT環境変数がリモートKVより優先されるべき")
+	assert.Equal(t, "remote-db", cfg.Database.Host)
+	assert.Equal(t, "require", cfg.Database.SSLMode)
+}
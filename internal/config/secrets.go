@@ -0,0 +1,191 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SecretResolver はURIスキーム(env://, file://, vault://など)で表現されたシークレット参照を
+// 実際の値に解決する。Load/LoadWithWatcherはUnmarshal後にresolveSecretsを通じて
+// Config内のすべてのstringフィールドへこれを適用する
+type SecretResolver interface {
+	// Scheme はこのリゾルバが処理するURIスキーム(末尾の"://"を含まない。例: "env")
+	Scheme() string
+	// Resolve はURIのスキームを除いた部分(例: "env://JWT_SECRET"なら"JWT_SECRET")を受け取り、
+	// 解決した値を返す
+	Resolve(ref string) (string, error)
+}
+
+// envResolver は環境変数からシークレットを解決する。例: env://JWT_SECRET
+type envResolver struct{}
+
+func (envResolver) Scheme() string { return "env" }
+
+func (envResolver) Resolve(ref string) (string, error) {
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("環境変数 %s が設定されていません", ref)
+	}
+	return value, nil
+}
+
+// fileResolver はファイルの中身(末尾の改行は除く)をシークレットとして解決する。例: file:///run/secrets/db_password
+type fileResolver struct{}
+
+func (fileResolver) Scheme() string { return "file" }
+
+func (fileResolver) Resolve(ref string) (string, error) {
+	// file://の後ろはそのまま絶対パスとして扱う(file:///run/secrets/xならref=/run/secrets/x)
+	data, err := os.ReadFile(ref)
+	if err != nil {
+		return "", fmt.Errorf("シークレットファイル %s の読み込みに失敗しました: %w", ref, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// vaultResolver はHashiCorp Vault KV v2 HTTP APIからシークレットを解決する。
+// 参照形式は vault://<mount>/data/<path>#<field> (例: vault://kv/data/sake#secret)。
+// VaultのアドレスとトークンはそれぞれVAULT_ADDR/VAULT_TOKEN環境変数から取得する
+type vaultResolver struct {
+	addr       string
+	token      string
+	httpClient *http.Client
+}
+
+func newVaultResolver() *vaultResolver {
+	return &vaultResolver{
+		addr:       os.Getenv("VAULT_ADDR"),
+		token:      os.Getenv("VAULT_TOKEN"),
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (vaultResolver) Scheme() string { return "vault" }
+
+// vaultKVv2Response はVault KV v2の `GET /v1/<mount>/data/<path>` レスポンスのうち必要な部分のみ
+type vaultKVv2Response struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+func (r *vaultResolver) Resolve(ref string) (string, error) {
+	if r.addr == "" {
+		return "", fmt.Errorf("VAULT_ADDRが設定されていません")
+	}
+	if r.token == "" {
+		return "", fmt.Errorf("VAULT_TOKENが設定されていません")
+	}
+
+	path, field, ok := strings.Cut(ref, "#")
+	if !ok {
+		return "", fmt.Errorf("vault://参照にはフィールド名が必要です(例: vault://kv/data/sake#secret): %s", ref)
+	}
+
+	url := strings.TrimRight(r.addr, "/") + "/v1/" + strings.TrimLeft(path, "/")
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("Vaultリクエストの作成に失敗しました: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", r.token)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Vaultへのリクエストに失敗しました: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("Vaultレスポンスの読み込みに失敗しました: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Vaultが異常なステータスを返しました: %d %s", resp.StatusCode, string(body))
+	}
+
+	var kv vaultKVv2Response
+	if err := json.Unmarshal(body, &kv); err != nil {
+		return "", fmt.Errorf("Vaultレスポンスのパースに失敗しました: %w", err)
+	}
+
+	value, ok := kv.Data.Data[field]
+	if !ok {
+		return "", fmt.Errorf("Vaultのシークレットにフィールド %s が見つかりません: %s", field, ref)
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("Vaultのフィールド %s は文字列ではありません: %s", field, ref)
+	}
+
+	return str, nil
+}
+
+// defaultResolvers はビルトインのSecretResolver一覧
+func defaultResolvers() []SecretResolver {
+	return []SecretResolver{
+		envResolver{},
+		fileResolver{},
+		newVaultResolver(),
+	}
+}
+
+// resolveSecrets はcfg内のすべてのstringフィールドを走査し、resolvers内のいずれかのスキームに
+// 一致する値(例: "vault://kv/data/sake#secret")を実際の値へ書き換える
+func resolveSecrets(cfg *Config, resolvers []SecretResolver) error {
+	byScheme := make(map[string]SecretResolver, len(resolvers))
+	for _, r := range resolvers {
+		byScheme[r.Scheme()] = r
+	}
+
+	return resolveSecretsInValue(reflect.ValueOf(cfg).Elem(), byScheme)
+}
+
+func resolveSecretsInValue(v reflect.Value, byScheme map[string]SecretResolver) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+
+		switch field.Kind() {
+		case reflect.Struct:
+			if err := resolveSecretsInValue(field, byScheme); err != nil {
+				return err
+			}
+		case reflect.String:
+			scheme, ref, ok := parseSecretRef(field.String())
+			if !ok {
+				continue
+			}
+			// env/file/vault以外の"scheme://"文字列(例: RemoteConfig.Endpointの"http://consul:8500")は
+			// シークレット参照ではなくただのURLなので、未登録スキームは無視して素通りさせる
+			resolver, ok := byScheme[scheme]
+			if !ok {
+				continue
+			}
+			resolved, err := resolver.Resolve(ref)
+			if err != nil {
+				return fmt.Errorf("シークレット参照 %s://%s の解決に失敗しました: %w", scheme, ref, err)
+			}
+			field.SetString(resolved)
+		}
+	}
+	return nil
+}
+
+// parseSecretRef は "scheme://ref" 形式の文字列をスキームと残りの部分に分解する。
+// 一致しない場合はok=falseを返す
+func parseSecretRef(s string) (scheme, ref string, ok bool) {
+	scheme, ref, found := strings.Cut(s, "://")
+	if !found || scheme == "" {
+		return "", "", false
+	}
+	return scheme, ref, true
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ValidationError はConfigの1フィールド分のバリデーション違反
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors は複数のValidationErrorをまとめて報告するための多重エラー
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("設定のバリデーションに失敗しました(%d件): %s", len(e), strings.Join(messages, "; "))
+}
+
+var validSSLModes = map[string]bool{
+	"disable":     true,
+	"require":     true,
+	"verify-ca":   true,
+	"verify-full": true,
+}
+
+var validLogLevels = map[string]bool{
+	"debug": true,
+	"info":  true,
+	"warn":  true,
+	"error": true,
+}
+
+var validLogFormats = map[string]bool{
+	"console": true,
+	"json":    true,
+}
+
+// Validate はConfig全体の妥当性を検証し、見つかった問題をすべてまとめてValidationErrorsとして返す
+// 1件でも見つかればエラーを返す(nilはすべて妥当であることを示す)
+func (c *Config) Validate() error {
+	var errs ValidationErrors
+
+	errs = append(errs, validateJWT(c.JWT)...)
+	errs = append(errs, validateServer(c.Server)...)
+	errs = append(errs, validateDatabase(c.Database)...)
+	errs = append(errs, validateValkey(c.Valkey)...)
+	errs = append(errs, validateCORS(c.CORS)...)
+	errs = append(errs, validateLogging(c.Logging)...)
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// MustValidate はValidateを実行し、失敗した場合panicする。main関数での起動時チェック用
+func (c *Config) MustValidate() {
+	if err := c.Validate(); err != nil {
+		panic(err)
+	}
+}
+
+func validateJWT(cfg JWTConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if len(cfg.Secret) < 32 {
+		errs = append(errs, ValidationError{Field: "jwt.secret", Message: "32バイト以上である必要があります"})
+	}
+
+	env := os.Getenv("ENV")
+	if env == "" {
+		env = "local"
+	}
+	if env != "local" && cfg.Secret == defaultJWTSecret {
+		errs = append(errs, ValidationError{Field: "jwt.secret", Message: "本番相当環境ではデフォルト値のままにできません"})
+	}
+
+	return errs
+}
+
+func validateServer(cfg ServerConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if cfg.Port < 1 || cfg.Port > 65535 {
+		errs = append(errs, ValidationError{Field: "server.port", Message: "1〜65535の範囲で指定してください"})
+	}
+
+	return errs
+}
+
+func validateDatabase(cfg DatabaseConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !validSSLModes[cfg.SSLMode] {
+		errs = append(errs, ValidationError{
+			Field:   "database.sslmode",
+			Message: "disable, require, verify-ca, verify-fullのいずれかを指定してください",
+		})
+	}
+
+	return errs
+}
+
+func validateValkey(cfg ValkeyConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if cfg.PoolSize < cfg.MinIdleConns {
+		errs = append(errs, ValidationError{
+			Field:   "valkey.poolSize",
+			Message: "valkey.minIdleConns以上である必要があります",
+		})
+	}
+
+	return errs
+}
+
+func validateCORS(cfg CORSConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if cfg.AllowCredentials {
+		for _, origin := range cfg.AllowedOrigins {
+			if origin == "*" {
+				errs = append(errs, ValidationError{
+					Field:   "cors.allowedOrigins",
+					Message: "allowCredentials=trueの場合は\"*\"を含められません(ブラウザに拒否されます)",
+				})
+				break
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateLogging(cfg LoggingConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !validLogLevels[cfg.Level] {
+		errs = append(errs, ValidationError{Field: "logging.level", Message: "debug, info, warn, errorのいずれかを指定してください"})
+	}
+
+	if !validLogFormats[cfg.Format] {
+		errs = append(errs, ValidationError{Field: "logging.format", Message: "console, jsonのいずれかを指定してください"})
+	}
+
+	return errs
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validConfigForTest() Config {
+	return Config{
+		Server: ServerConfig{Port: 8080, Mode: "debug"},
+		Database: DatabaseConfig{
+			Host: "localhost", Port: 5432, Database: "db", User: "user", Password: "pass", SSLMode: "disable",
+		},
+		Valkey: ValkeyConfig{Host: "localhost", Port: 6379, PoolSize: 10, MinIdleConns: 5},
+		JWT:    JWTConfig{Secret: "this-is-a-sufficiently-long-secret-value"},
+		CORS:   CORSConfig{AllowedOrigins: []string{"http://localhost:3000"}, AllowCredentials: true},
+		Logging: LoggingConfig{
+			Level: "info", Format: "json",
+		},
+	}
+}
+
+func TestConfig_Validate_Valid(t *testing.T) {
+	cfg := validConfigForTest()
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_CollectsMultipleErrors(t *testing.T) {
+	cfg := validConfigForTest()
+	cfg.Server.Port = 0
+	cfg.Database.SSLMode = "bogus"
+	cfg.Logging.Level = "trace"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+
+	var validationErrs ValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	assert.GreaterOrEqual(t, len(validationErrs), 3)
+}
+
+func TestConfig_Validate_JWTSecretTooShort(t *testing.T) {
+	cfg := validConfigForTest()
+	cfg.JWT.Secret = "too-short"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "jwt.secret")
+}
+
+func TestConfig_Validate_ServerPortOutOfRange(t *testing.T) {
+	cfg := validConfigForTest()
+	cfg.Server.Port = 70000
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server.port")
+}
+
+func TestConfig_Validate_InvalidSSLMode(t *testing.T) {
+	cfg := validConfigForTest()
+	cfg.Database.SSLMode = "maybe"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database.sslmode")
+}
+
+func TestConfig_Validate_ValkeyPoolSizeTooSmall(t *testing.T) {
+	cfg := validConfigForTest()
+	cfg.Valkey.PoolSize = 2
+	cfg.Valkey.MinIdleConns = 5
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "valkey.poolSize")
+}
+
+func TestConfig_Validate_CORSWildcardWithCredentials(t *testing.T) {
+	cfg := validConfigForTest()
+	cfg.CORS.AllowCredentials = true
+	cfg.CORS.AllowedOrigins = []string{"*"}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cors.allowedOrigins")
+}
+
+func TestConfig_Validate_CORSWildcardWithoutCredentials_Allowed(t *testing.T) {
+	cfg := validConfigForTest()
+	cfg.CORS.AllowCredentials = false
+	cfg.CORS.AllowedOrigins = []string{"*"}
+
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_InvalidLoggingLevelAndFormat(t *testing.T) {
+	cfg := validConfigForTest()
+	cfg.Logging.Level = "verbose"
+	cfg.Logging.Format = "xml"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logging.level")
+	assert.Contains(t, err.Error(), "logging.format")
+}
+
+func TestConfig_MustValidate_PanicsOnInvalidConfig(t *testing.T) {
+	cfg := validConfigForTest()
+	cfg.Server.Port = 0
+
+	assert.Panics(t, func() {
+		cfg.MustValidate()
+	})
+}
+
+func TestConfig_MustValidate_DoesNotPanicOnValidConfig(t *testing.T) {
+	cfg := validConfigForTest()
+
+	assert.NotPanics(t, func() {
+		cfg.MustValidate()
+	})
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+
+	"github.com/sake-kasu/sake-hack-backend/internal/logger"
+)
+
+// remoteWatchInterval はリモート設定プロバイダ(Consul/etcd3)へ変更有無を問い合わせる間隔
+const remoteWatchInterval = 5 * time.Second
+
+// SubscriberFunc はセクション変更時に呼ばれるコールバック。oldとnewは変更前後のセクション値(例: LoggingConfig)
+type SubscriberFunc func(old, new any)
+
+// RestartRequiredFunc はreload:"restart"が付与されたフィールドが変更された際に呼ばれるコールバック
+// fieldsは変更されたフィールドのドット区切りパス(例: "database.host")
+type RestartRequiredFunc func(fields []string)
+
+// Watcher はviper.WatchConfig()によるファイル変更監視と、セクション単位のSubscribe通知を扱う
+type Watcher struct {
+	mu              sync.RWMutex
+	v               *viper.Viper
+	current         *Config
+	subscribers     map[string][]SubscriberFunc
+	restartHandlers []RestartRequiredFunc
+}
+
+// newWatcher はWatcherを構築する(まだ監視は開始しない)
+func newWatcher(v *viper.Viper, initial *Config) *Watcher {
+	return &Watcher{
+		v:           v,
+		current:     initial,
+		subscribers: make(map[string][]SubscriberFunc),
+	}
+}
+
+// Subscribe はsection(Configのmapstructureタグ名。例: "logging", "cors", "server", "valkey")の
+// 変更を購読する。sectionが変更されるたびcbがold/newのセクション値で呼ばれる
+func (w *Watcher) Subscribe(section string, cb SubscriberFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers[section] = append(w.subscribers[section], cb)
+}
+
+// OnRestartRequired はreload:"restart"フィールドの変更時に呼ばれるコールバックを登録する
+func (w *Watcher) OnRestartRequired(cb RestartRequiredFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.restartHandlers = append(w.restartHandlers, cb)
+}
+
+// Current は直近に読み込んだConfigのスナップショットを返す
+func (w *Watcher) Current() *Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// start はviper.WatchConfig()を開始し、ファイル変更のたびhandleChangeを呼ぶ
+func (w *Watcher) start() {
+	w.v.OnConfigChange(func(_ fsnotify.Event) {
+		w.handleChange()
+	})
+	w.v.WatchConfig()
+}
+
+// startRemoteWatch はローカルのfsnotify監視(start)に相当する、リモート設定プロバイダ版の監視を開始する。
+// viper.WatchRemoteConfigOnChannel自体は変更通知チャネルを準備するだけなので、
+// remoteWatchIntervalごとにWatchRemoteConfigをポーリングしてhandleChangeへ反映する
+func startRemoteWatch(v *viper.Viper, w *Watcher) {
+	ctx := context.Background()
+
+	if err := v.WatchRemoteConfigOnChannel(); err != nil {
+		logger.Error(ctx, "リモート設定の監視開始に失敗しました", logger.Err(err))
+		return
+	}
+
+	go func() {
+		for {
+			time.Sleep(remoteWatchInterval)
+
+			if err := v.WatchRemoteConfig(); err != nil {
+				logger.Error(ctx, "リモート設定の再読み込みに失敗しました", logger.Err(err))
+				continue
+			}
+
+			w.handleChange()
+		}
+	}()
+}
+
+// handleChange は設定ファイル変更時に新しいConfigへ再Unmarshalし、セクションごとに差分を検出して通知する
+func (w *Watcher) handleChange() {
+	ctx := context.Background()
+
+	var newConfig Config
+	if err := w.v.Unmarshal(&newConfig); err != nil {
+		logger.Error(ctx, "設定ファイルの再読み込みに失敗しました", logger.Err(err))
+		return
+	}
+
+	if err := resolveSecrets(&newConfig, defaultResolvers()); err != nil {
+		logger.Error(ctx, "シークレットの解決に失敗しました", logger.Err(err))
+		return
+	}
+
+	w.mu.Lock()
+	oldConfig := w.current
+	w.current = &newConfig
+	subscribers := w.subscribers
+	restartHandlers := w.restartHandlers
+	w.mu.Unlock()
+
+	changes := diffStruct("", reflect.ValueOf(*oldConfig), reflect.ValueOf(newConfig))
+	if len(changes) == 0 {
+		return
+	}
+
+	restartFields := make([]string, 0)
+	changedSections := make(map[string]bool)
+
+	for _, change := range changes {
+		logger.Info(ctx, "設定変更を検出しました",
+			logger.String("field", change.path),
+			logger.String("old", redactIfSecret(change)),
+			logger.String("new", redactIfSecretNew(change)),
+		)
+
+		if change.restart {
+			restartFields = append(restartFields, change.path)
+			continue
+		}
+		changedSections[change.topLevel] = true
+	}
+
+	if len(restartFields) > 0 {
+		for _, cb := range restartHandlers {
+			cb(restartFields)
+		}
+	}
+
+	oldValue := reflect.ValueOf(*oldConfig)
+	newValue := reflect.ValueOf(newConfig)
+	for section := range changedSections {
+		oldSection, newSection, ok := sectionValues(oldValue, newValue, section)
+		if !ok {
+			continue
+		}
+		for _, cb := range subscribers[section] {
+			cb(oldSection, newSection)
+		}
+	}
+}
+
+// sectionValues はConfig構造体のトップレベルフィールドのうちmapstructureタグがsectionと一致するものを返す
+func sectionValues(oldValue, newValue reflect.Value, section string) (old, new any, ok bool) {
+	t := oldValue.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("mapstructure")
+		if name == section {
+			return oldValue.Field(i).Interface(), newValue.Field(i).Interface(), true
+		}
+	}
+	return nil, nil, false
+}
+
+// redactIfSecret/redactIfSecretNew はsecret:"true"が付与されたフィールドの値をログへ出さないようにする
+func redactIfSecret(c fieldChange) string {
+	if c.secret {
+		return "***"
+	}
+	return toLogString(c.oldValue)
+}
+
+func redactIfSecretNew(c fieldChange) string {
+	if c.secret {
+		return "***"
+	}
+	return toLogString(c.newValue)
+}
+
+func toLogString(v any) string {
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupWatcherTestConfig はtmpDir/config/config.yamlにcontentを書き込み、カレントディレクトリを切り替える
+func setupWatcherTestConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	configDir := filepath.Join(tmpDir, "config")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+
+	configPath := filepath.Join(configDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(originalWd))
+	})
+	require.NoError(t, os.Chdir(tmpDir))
+
+	t.Setenv("ENV", "local")
+
+	return configPath
+}
+
+func TestWatcher_Subscribe_FiresOnSectionChange(t *testing.T) {
+	configPath := setupWatcherTestConfig(t, `
+logging:
+  level: debug
+  format: console
+`)
+
+	cfg, watcher, err := LoadWithWatcher()
+	require.NoError(t, err)
+	require.Equal(t, "debug", cfg.Logging.Level)
+
+	type call struct {
+		old LoggingConfig
+		new LoggingConfig
+	}
+	calls := make(chan call, 1)
+	watcher.Subscribe("logging", func(old, new any) {
+		calls <- call{old: old.(LoggingConfig), new: new.(LoggingConfig)}
+	})
+
+	rewriteConfig(t, configPath, `
+logging:
+  level: warn
+  format: console
+`)
+
+	select {
+	case c := <-calls:
+		assert.Equal(t, "debug", c.old.Level)
+		assert.Equal(t, "warn", c.new.Level)
+	case <-time.After(5 * time.Second):
+		t.Fatal("設定変更のコールバックがタイムアウトしました")
+	}
+}
+
+func TestWatcher_RestartRequiredFields(t *testing.T) {
+	configPath := setupWatcherTestConfig(t, `
+database:
+  host: original-db
+  port: 5432
+`)
+
+	_, watcher, err := LoadWithWatcher()
+	require.NoError(t, err)
+
+	fieldsCh := make(chan []string, 1)
+	watcher.OnRestartRequired(func(fields []string) {
+		fieldsCh <- fields
+	})
+
+	rewriteConfig(t, configPath, `
+database:
+  host: changed-db
+  port: 5432
+`)
+
+	select {
+	case fields := <-fieldsCh:
+		assert.Contains(t, fields, "database.host")
+	case <-time.After(5 * time.Second):
+		t.Fatal("RestartRequiredのコールバックがタイムアウトしました")
+	}
+}
+
+func TestWatcher_Subscribe_DoesNotFireForUnrelatedSection(t *testing.T) {
+	configPath := setupWatcherTestConfig(t, `
+logging:
+  level: debug
+  format: console
+cors:
+  allowedOrigins:
+    - "http://localhost:3000"
+`)
+
+	_, watcher, err := LoadWithWatcher()
+	require.NoError(t, err)
+
+	corsCalled := make(chan struct{}, 1)
+	watcher.Subscribe("cors", func(old, new any) {
+		corsCalled <- struct{}{}
+	})
+
+	loggingCalled := make(chan struct{}, 1)
+	watcher.Subscribe("logging", func(old, new any) {
+		loggingCalled <- struct{}{}
+	})
+
+	rewriteConfig(t, configPath, `
+logging:
+  level: error
+  format: console
+cors:
+  allowedOrigins:
+    - "http://localhost:3000"
+`)
+
+	select {
+	case <-loggingCalled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("loggingセクションのコールバックがタイムアウトしました")
+	}
+
+	select {
+	case <-corsCalled:
+		t.Fatal("変更されていないcorsセクションのコールバックが呼ばれてしまった")
+	case <-time.After(200 * time.Millisecond):
+		// corsは変更されていないので呼ばれないのが正しい
+	}
+}
+
+// rewriteConfig は設定ファイルをその場で上書きする。viperのfsnotify監視はこの書き込みイベントを検知する
+func rewriteConfig(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestDiffStruct_RedactsSecretFields(t *testing.T) {
+	old := Config{JWT: JWTConfig{Secret: "old-secret", Expiration: 100}}
+	newCfg := Config{JWT: JWTConfig{Secret: "new-secret", Expiration: 200}}
+
+	changes := diffStruct("", reflect.ValueOf(old), reflect.ValueOf(newCfg))
+
+	var secretChange, expirationChange *fieldChange
+	for i := range changes {
+		switch changes[i].path {
+		case "jwt.secret":
+			secretChange = &changes[i]
+		case "jwt.expiration":
+			expirationChange = &changes[i]
+		}
+	}
+
+	require.NotNil(t, secretChange)
+	require.NotNil(t, expirationChange)
+
+	assert.True(t, secretChange.secret)
+	assert.Equal(t, "***", redactIfSecret(*secretChange))
+	assert.Equal(t, "***", redactIfSecretNew(*secretChange))
+
+	assert.False(t, expirationChange.secret)
+	assert.Equal(t, "100", redactIfSecret(*expirationChange))
+	assert.Equal(t, "200", redactIfSecretNew(*expirationChange))
+}
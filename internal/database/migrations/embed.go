@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// SQLFiles はsql/以下のバージョン管理されたマイグレーションファイルを埋め込む
+//
+//go:embed sql/*.sql
+var SQLFiles embed.FS
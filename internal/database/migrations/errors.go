@@ -0,0 +1,11 @@
+package migrations
+
+import "errors"
+
+// ErrAlreadyLocked は他のプロセスがマイグレーションのアドバイザリロックを保持している場合に返される
+// (一時的な状態であり、ロック保持側の完了を待って再試行すればよい)
+var ErrAlreadyLocked = errors.New("migrations: 他のプロセスがマイグレーションを実行中です(アドバイザリロック取得失敗)")
+
+// ErrDirtyState はschema_migrationsが前回の失敗で不整合な状態(dirty)のまま残っている場合に返される
+// (手動での調査・修復が必要な状態であり、自動リトライでは解消しない)
+var ErrDirtyState = errors.New("migrations: 前回のマイグレーションが失敗しdirty状態です。手動での確認が必要です")
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations_SortsByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0002_add_index.up.sql":    &fstest.MapFile{Data: []byte("CREATE INDEX foo ON bar (baz);")},
+		"sql/0002_add_index.down.sql":  &fstest.MapFile{Data: []byte("DROP INDEX foo;")},
+		"sql/0001_init_schema.up.sql":  &fstest.MapFile{Data: []byte("CREATE TABLE bar ();")},
+		"sql/0001_init_schema.down.sql": &fstest.MapFile{Data: []byte("DROP TABLE bar;")},
+	}
+
+	migrations, err := loadMigrations(fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "init_schema", migrations[0].Name)
+	assert.Equal(t, "CREATE TABLE bar ();", migrations[0].UpSQL)
+	assert.Equal(t, "DROP TABLE bar;", migrations[0].DownSQL)
+
+	assert.Equal(t, int64(2), migrations[1].Version)
+	assert.Equal(t, "add_index", migrations[1].Name)
+}
+
+func TestLoadMigrations_IgnoresUnrelatedFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0001_init.up.sql":   &fstest.MapFile{Data: []byte("CREATE TABLE x ();")},
+		"sql/0001_init.down.sql": &fstest.MapFile{Data: []byte("DROP TABLE x;")},
+		"sql/README.md":          &fstest.MapFile{Data: []byte("not a migration")},
+	}
+
+	migrations, err := loadMigrations(fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 1)
+	assert.Equal(t, int64(1), migrations[0].Version)
+}
+
+func TestLoadMigrations_EmbeddedSQLFiles(t *testing.T) {
+	migrations, err := loadMigrations(SQLFiles)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for _, m := range migrations {
+		assert.NotEmpty(t, m.UpSQL)
+		assert.NotEmpty(t, m.DownSQL)
+	}
+}
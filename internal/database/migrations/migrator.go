@@ -0,0 +1,281 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+)
+
+// advisoryLockKey はマイグレーション実行の排他に使うPostgreSQLセッションレベルアドバイザリロックのキー
+// モジュール固有の値で固定し、同一DBに対する複数Podの同時起動がお互いに競合しないようにする
+const advisoryLockKey int64 = 0x73616b655f686163 // "sake_hack"の先頭8バイトを16進数として採用
+
+// Migrator はschema_migrationsテーブルを用いてDBスキーマのバージョンを管理する
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// dbConn はschema_migrationsへのクエリ実行に必要な、*sql.DBと*sql.Connに共通のインターフェース。
+// pg_try_advisory_lock/pg_advisory_unlockはセッション(コネクション)スコープのため、
+// ロック取得からマイグレーション適用、解放までを同一の*sql.Conn上で行う必要がある
+type dbConn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// NewMigrator はfsys配下のsql/*.sqlからMigratorを構築する
+func NewMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := loadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// MigrationStatus はStatus()が返す1バージョン分の適用状況
+type MigrationStatus struct {
+	Version int64
+	Name    string
+	Applied bool
+}
+
+// withAdvisoryLock はコネクションプールから1本だけコネクションを取り出し、その上でpg_try_advisory_lockを
+// 取得した状態でfnを実行し、完了後に同じコネクション上でロックを解放する。
+// pg_try_advisory_lock/pg_advisory_unlockはセッションスコープなので、プールから取った別のコネクションで
+// unlockしても無意味(サイレントにno-opする)になる。そのため、ロック・マイグレーション適用・unlockの
+// すべてをこの1本のコネクション上で完結させる
+func (m *Migrator) withAdvisoryLock(ctx context.Context, fn func(ctx context.Context, conn dbConn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("コネクションの確保に失敗しました: %w", err)
+	}
+	defer conn.Close()
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", advisoryLockKey).Scan(&acquired); err != nil {
+		return fmt.Errorf("アドバイザリロックの取得に失敗しました: %w", err)
+	}
+	if !acquired {
+		return ErrAlreadyLocked
+	}
+	defer func() {
+		_, _ = conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockKey)
+	}()
+
+	return fn(ctx, conn)
+}
+
+// ensureMigrationsTable はschema_migrationsテーブルが存在しなければ作成する
+func (m *Migrator) ensureMigrationsTable(ctx context.Context, conn dbConn) error {
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			dirty      BOOLEAN NOT NULL DEFAULT false,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("schema_migrationsテーブルの作成に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// currentState は現在のバージョンとdirtyフラグを返す。未適用の場合はversion=0, ok=false
+func (m *Migrator) currentState(ctx context.Context, conn dbConn) (version int64, dirty bool, ok bool, err error) {
+	row := conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations ORDER BY version DESC LIMIT 1")
+	if err := row.Scan(&version, &dirty); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, false, nil
+		}
+		return 0, false, false, fmt.Errorf("現在のマイグレーションバージョン取得に失敗しました: %w", err)
+	}
+	return version, dirty, true, nil
+}
+
+// appliedVersions は適用済み(dirty=false)の全バージョンをバージョン番号をキーとするmapで返す
+func (m *Migrator) appliedVersions(ctx context.Context, conn dbConn) (map[int64]bool, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations WHERE dirty = false")
+	if err != nil {
+		return nil, fmt.Errorf("適用済みマイグレーション一覧の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int64]bool{}
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("適用済みマイグレーション一覧のスキャンに失敗しました: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// Up は未適用のマイグレーションを全てバージョン昇順で適用する
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withAdvisoryLock(ctx, func(ctx context.Context, conn dbConn) error {
+		if err := m.ensureMigrationsTable(ctx, conn); err != nil {
+			return err
+		}
+
+		_, dirty, _, err := m.currentState(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return ErrDirtyState
+		}
+
+		applied, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if applied[mig.Version] {
+				continue
+			}
+			if err := m.applyUp(ctx, conn, mig); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// applyUp は1件のマイグレーションをUp方向に適用する。
+// dirtyフラグの立て下げはマイグレーション本体とは別コミットで行うことで、
+// プロセスがUpSQL実行中にクラッシュしてもdirty=trueがDBに残り、次回起動時にErrDirtyStateとして検知できるようにする
+func (m *Migrator) applyUp(ctx context.Context, conn dbConn, mig Migration) error {
+	if _, err := conn.ExecContext(ctx,
+		"INSERT INTO schema_migrations (version, dirty) VALUES ($1, true)", mig.Version); err != nil {
+		return fmt.Errorf("マイグレーション記録の挿入に失敗しました(version=%d): %w", mig.Version, err)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("トランザクション開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, mig.UpSQL); err != nil {
+		return fmt.Errorf("マイグレーションの適用に失敗しました(version=%d): %w", mig.Version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("トランザクションのコミットに失敗しました(version=%d): %w", mig.Version, err)
+	}
+
+	if _, err := conn.ExecContext(ctx,
+		"UPDATE schema_migrations SET dirty = false WHERE version = $1", mig.Version); err != nil {
+		return fmt.Errorf("マイグレーション記録の更新に失敗しました(version=%d): %w", mig.Version, err)
+	}
+
+	return nil
+}
+
+// Down は適用済みマイグレーションを新しい方からn件ロールバックする
+func (m *Migrator) Down(ctx context.Context, n int) error {
+	return m.withAdvisoryLock(ctx, func(ctx context.Context, conn dbConn) error {
+		if err := m.ensureMigrationsTable(ctx, conn); err != nil {
+			return err
+		}
+
+		_, dirty, _, err := m.currentState(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return ErrDirtyState
+		}
+
+		applied, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		// バージョン降順でn件分だけロールバック対象を集める
+		targets := make([]Migration, 0, n)
+		for i := len(m.migrations) - 1; i >= 0 && len(targets) < n; i-- {
+			mig := m.migrations[i]
+			if applied[mig.Version] {
+				targets = append(targets, mig)
+			}
+		}
+
+		for _, mig := range targets {
+			if err := m.applyDown(ctx, conn, mig); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// applyDown は1件のマイグレーションをDown方向に適用する。
+// applyUpと同様、dirtyフラグの立て下げはロールバック本体とは別コミットで行う
+func (m *Migrator) applyDown(ctx context.Context, conn dbConn, mig Migration) error {
+	if _, err := conn.ExecContext(ctx,
+		"UPDATE schema_migrations SET dirty = true WHERE version = $1", mig.Version); err != nil {
+		return fmt.Errorf("マイグレーション記録の更新に失敗しました(version=%d): %w", mig.Version, err)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("トランザクション開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, mig.DownSQL); err != nil {
+		return fmt.Errorf("マイグレーションのロールバックに失敗しました(version=%d): %w", mig.Version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("トランザクションのコミットに失敗しました(version=%d): %w", mig.Version, err)
+	}
+
+	if _, err := conn.ExecContext(ctx,
+		"DELETE FROM schema_migrations WHERE version = $1", mig.Version); err != nil {
+		return fmt.Errorf("マイグレーション記録の削除に失敗しました(version=%d): %w", mig.Version, err)
+	}
+
+	return nil
+}
+
+// Status は全マイグレーションの適用状況をバージョン昇順で返す
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.ensureMigrationsTable(ctx, m.db); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.appliedVersions(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		statuses = append(statuses, MigrationStatus{
+			Version: mig.Version,
+			Name:    mig.Name,
+			Applied: applied[mig.Version],
+		})
+	}
+
+	return statuses, nil
+}
+
+// Version は現在のスキーマバージョンとdirty状態を返す。未適用の場合はok=false
+func (m *Migrator) Version(ctx context.Context) (version int64, dirty bool, ok bool, err error) {
+	if err := m.ensureMigrationsTable(ctx, m.db); err != nil {
+		return 0, false, false, err
+	}
+	return m.currentState(ctx, m.db)
+}
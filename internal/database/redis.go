@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisConfig はRedis(go-redis)接続設定
+// Valkeyと同一のインフラに対して張ることも多いため、フィールド構成はValkeyConfigに合わせている
+type RedisConfig struct {
+	Host         string
+	Port         int
+	Password     string
+	Database     int
+	PoolSize     int
+	MinIdleConns int
+	MaxRetries   int
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+// NewRedisClient はgo-redisクライアントを作成する
+func NewRedisClient(config RedisConfig) (*redis.Client, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:         fmt.Sprintf("%s:%d", config.Host, config.Port),
+		Password:     config.Password,
+		DB:           config.Database,
+		PoolSize:     config.PoolSize,
+		MinIdleConns: config.MinIdleConns,
+		MaxRetries:   config.MaxRetries,
+		DialTimeout:  config.DialTimeout,
+		ReadTimeout:  config.ReadTimeout,
+		WriteTimeout: config.WriteTimeout,
+	})
+
+	// 接続確認
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("redisへのPingに失敗しました: %w", err)
+	}
+
+	return client, nil
+}
+
+// HealthCheckRedis はRedisの接続状態を確認する
+func HealthCheckRedis(ctx context.Context, client *redis.Client) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("redisへのヘルスチェックに失敗しました: %w", err)
+	}
+
+	return nil
+}
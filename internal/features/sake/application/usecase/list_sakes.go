@@ -10,10 +10,13 @@ import (
 
 // ListSakesInput 酒一覧取得の入力パラメータ
 type ListSakesInput struct {
-	TypeID    *int32
-	BreweryID *int32
-	Offset    int32
-	Limit     int32
+	TypeID      *int32
+	BreweryID   *int32
+	NearBrewery *repository.NearBreweryFilter
+	Cursor      *string
+	Offset      int32
+	Limit       int32
+	BypassCache bool
 }
 
 // ListSakesOutput 酒一覧取得の出力
@@ -41,7 +44,8 @@ func NewListSakesUsecase(sakeRepo repository.SakeRepository) *ListSakesUsecase {
 
 // Execute 酒一覧を取得する
 func (u *ListSakesUsecase) Execute(ctx context.Context, input ListSakesInput) (*ListSakesOutput, error) {
-	defer logger.TraceMethodAuto(ctx, input)()
+	ctx, done := logger.TraceMethodAuto(ctx, input)
+	defer done()
 
 	// バリデーション
 	if input.Offset < 0 {
@@ -53,10 +57,13 @@ func (u *ListSakesUsecase) Execute(ctx context.Context, input ListSakesInput) (*
 
 	// リポジトリから取得
 	sakes, pagination, err := u.sakeRepo.List(ctx, repository.ListSakesFilter{
-		TypeID:    input.TypeID,
-		BreweryID: input.BreweryID,
-		Offset:    input.Offset,
-		Limit:     input.Limit,
+		TypeID:      input.TypeID,
+		BreweryID:   input.BreweryID,
+		NearBrewery: input.NearBrewery,
+		Cursor:      input.Cursor,
+		Offset:      input.Offset,
+		Limit:       input.Limit,
+		BypassCache: input.BypassCache,
 	})
 	if err != nil {
 		return nil, err
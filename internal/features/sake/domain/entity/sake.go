@@ -44,4 +44,8 @@ type Pagination struct {
 	Total  int64
 	Offset int32
 	Limit  int32
+	// NextCursor はカーソルページネーション使用時、次ページを取得するための不透明なカーソル
+	// (offset/limitベースのページネーションではnil)
+	NextCursor *string
+	HasMore    bool
 }
@@ -6,12 +6,24 @@ import (
 	"github.com/sake-kasu/sake-hack-backend/internal/features/sake/domain/entity"
 )
 
+// NearBreweryFilter 酒造の位置を中心とした範囲検索条件
+type NearBreweryFilter struct {
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters float64
+}
+
 // ListSakesFilter 酒一覧取得のフィルター条件
 type ListSakesFilter struct {
-	TypeID    *int32
-	BreweryID *int32
-	Offset    int32
-	Limit     int32
+	TypeID      *int32
+	BreweryID   *int32
+	NearBrewery *NearBreweryFilter
+	// Cursor が指定されている場合、Offsetの代わりにキーセットページネーションを行う
+	Cursor *string
+	Offset int32
+	Limit  int32
+	// BypassCache はtrueの場合、読み取りキャッシュを経由せずinnerのSakeRepositoryへ直接問い合わせる
+	BypassCache bool
 }
 
 // SakeRepository 酒リポジトリのインターフェース
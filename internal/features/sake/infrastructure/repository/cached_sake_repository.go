@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/sake-kasu/sake-hack-backend/internal/cache"
+	"github.com/sake-kasu/sake-hack-backend/internal/features/sake/domain/entity"
+	"github.com/sake-kasu/sake-hack-backend/internal/features/sake/domain/repository"
+	"github.com/sake-kasu/sake-hack-backend/internal/logger"
+	"github.com/sake-kasu/sake-hack-backend/internal/metrics"
+)
+
+// cacheTagAllSakes は酒一覧キャッシュ全体を一括無効化するためのタグ
+const cacheTagAllSakes = "sake:*"
+
+// sakeListCacheKeyPrefix は酒一覧キャッシュのキー空間プレフィックス
+// 将来、酒の更新系usecaseが追加された際にDeleteByPrefix(ctx, sakeListCacheKeyPrefix)で
+// 一覧キャッシュ全体を無効化できるようにキー構成をこのプレフィックスで統一する
+const sakeListCacheKeyPrefix = "sake:list:"
+
+// cachedSakeRepository はSakeRepositoryを読み取り透過キャッシュでラップするデコレータ
+//
+// キャッシュはListSakesUsecase.Execute自体ではなく、この下位のリポジトリ層に差し込んでいる。
+// SakeRepositoryインターフェイスは既にキャッシュキーの構成要素(フィルター)をそのまま引数に
+// 取っており、usecaseを経由しない将来の呼び出し元(例: 別usecaseからの再利用や管理系バッチ)にも
+// 同じキャッシュ透過性を自動的に効かせられる。usecase層に持たせるとキャッシュ方針がusecaseごとに
+// バラバラになりやすいが、リポジトリ層なら1箇所に集約できる。BypassCacheはInput→Filterとして
+// そのまま透過されるため、呼び出し元からの制御性はusecase直付けの場合と変わらない
+type cachedSakeRepository struct {
+	inner repository.SakeRepository
+	cache cache.Cache
+	ttl   time.Duration
+	sf    singleflight.Group
+}
+
+// NewCachedSakeRepository はinnerをcacheで読み取り透過キャッシュするSakeRepositoryを構築する
+func NewCachedSakeRepository(inner repository.SakeRepository, c cache.Cache, ttl time.Duration) repository.SakeRepository {
+	return &cachedSakeRepository{
+		inner: inner,
+		cache: c,
+		ttl:   ttl,
+	}
+}
+
+// cachedListResult はキャッシュへ保存/復元するList結果のペイロード
+type cachedListResult struct {
+	Sakes      []entity.Sake     `json:"sakes"`
+	Pagination entity.Pagination `json:"pagination"`
+}
+
+// List はキャッシュを経由して酒一覧を取得する。BypassCacheが指定された場合は常にinnerへ直接問い合わせる
+func (r *cachedSakeRepository) List(ctx context.Context, filter repository.ListSakesFilter) ([]entity.Sake, entity.Pagination, error) {
+	if filter.BypassCache {
+		return r.inner.List(ctx, filter)
+	}
+
+	key := cacheKeyForFilter(filter)
+
+	raw, found, err := r.cache.Get(ctx, key)
+	if err != nil {
+		metrics.IncCacheError()
+		logger.Warn(ctx, "キャッシュの取得に失敗しました", logger.String("key", key), logger.Err(err))
+	} else if found {
+		var result cachedListResult
+		if err := json.Unmarshal(raw, &result); err == nil {
+			metrics.IncCacheHit()
+			logger.Debug(ctx, "キャッシュヒット", logger.String("key", key))
+			return result.Sakes, result.Pagination, nil
+		}
+	}
+	metrics.IncCacheMiss()
+	logger.Debug(ctx, "キャッシュミス", logger.String("key", key))
+
+	value, err, shared := r.sf.Do(key, func() (interface{}, error) {
+		sakes, pagination, err := r.inner.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		result := cachedListResult{Sakes: sakes, Pagination: pagination}
+		if raw, err := json.Marshal(result); err == nil {
+			if err := r.cache.Set(ctx, key, raw, r.ttl, cacheTagsForFilter(filter)); err != nil {
+				metrics.IncCacheError()
+				logger.Warn(ctx, "キャッシュの保存に失敗しました", logger.String("key", key), logger.Err(err))
+			}
+		}
+
+		return result, nil
+	})
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+	if shared {
+		metrics.IncCacheSingleflightShared()
+	}
+
+	result := value.(cachedListResult)
+	return result.Sakes, result.Pagination, nil
+}
+
+// cacheKeyForFilter はListSakesFilterから安定したキャッシュキーを導出する
+// TypeID/BreweryIDなどのポインタフィールドはnilかどうかも区別してエンコードする
+func cacheKeyForFilter(filter repository.ListSakesFilter) string {
+	type keyFilter struct {
+		TypeID      *int32                        `json:"type_id"`
+		BreweryID   *int32                        `json:"brewery_id"`
+		NearBrewery *repository.NearBreweryFilter `json:"near_brewery"`
+		Cursor      *string                       `json:"cursor"`
+		Offset      int32                         `json:"offset"`
+		Limit       int32                         `json:"limit"`
+	}
+
+	// json.Marshalに失敗するフィールドは存在しないため、エラーは無視できる
+	raw, _ := json.Marshal(keyFilter{
+		TypeID:      filter.TypeID,
+		BreweryID:   filter.BreweryID,
+		NearBrewery: filter.NearBrewery,
+		Cursor:      filter.Cursor,
+		Offset:      filter.Offset,
+		Limit:       filter.Limit,
+	})
+
+	sum := sha256.Sum256(raw)
+	return sakeListCacheKeyPrefix + hex.EncodeToString(sum[:])
+}
+
+// cacheTagsForFilter はフィルターに応じた無効化タグを返す
+func cacheTagsForFilter(filter repository.ListSakesFilter) []string {
+	tags := []string{cacheTagAllSakes}
+
+	if filter.TypeID != nil {
+		tags = append(tags, fmt.Sprintf("sake_type:%d", *filter.TypeID))
+	}
+	if filter.BreweryID != nil {
+		tags = append(tags, fmt.Sprintf("brewery:%d", *filter.BreweryID))
+	}
+
+	return tags
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sake-kasu/sake-hack-backend/internal/cache"
+	"github.com/sake-kasu/sake-hack-backend/internal/features/sake/domain/entity"
+	"github.com/sake-kasu/sake-hack-backend/internal/features/sake/domain/repository"
+)
+
+// countingSakeRepository はDB呼び出し回数を数えるだけのテスト用SakeRepository実装
+type countingSakeRepository struct {
+	calls  atomic.Int32
+	delay  time.Duration
+	sakes  []entity.Sake
+	paging entity.Pagination
+}
+
+func (r *countingSakeRepository) List(ctx context.Context, filter repository.ListSakesFilter) ([]entity.Sake, entity.Pagination, error) {
+	r.calls.Add(1)
+	if r.delay > 0 {
+		time.Sleep(r.delay)
+	}
+	return r.sakes, r.paging, nil
+}
+
+func TestCachedSakeRepository_ConcurrentIdenticalRequests_CollapseToOneDBCall(t *testing.T) {
+	inner := &countingSakeRepository{
+		delay:  20 * time.Millisecond,
+		sakes:  []entity.Sake{{ID: 1, Name: "獺祭"}},
+		paging: entity.Pagination{Total: 1, Limit: 20},
+	}
+	lruCache, err := cache.NewLRUCache(100)
+	require.NoError(t, err)
+
+	repo := NewCachedSakeRepository(inner, lruCache, time.Minute)
+
+	typeID := int32(1)
+	filter := repository.ListSakesFilter{TypeID: &typeID, Limit: 20}
+
+	const concurrency = 100
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			sakes, _, err := repo.List(context.Background(), filter)
+			assert.NoError(t, err)
+			assert.Len(t, sakes, 1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), inner.calls.Load())
+}
+
+func TestCachedSakeRepository_BypassCache_AlwaysCallsInner(t *testing.T) {
+	inner := &countingSakeRepository{
+		sakes:  []entity.Sake{{ID: 1, Name: "獺祭"}},
+		paging: entity.Pagination{Total: 1, Limit: 20},
+	}
+	lruCache, err := cache.NewLRUCache(100)
+	require.NoError(t, err)
+
+	repo := NewCachedSakeRepository(inner, lruCache, time.Minute)
+
+	filter := repository.ListSakesFilter{Limit: 20, BypassCache: true}
+
+	_, _, err = repo.List(context.Background(), filter)
+	require.NoError(t, err)
+	_, _, err = repo.List(context.Background(), filter)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), inner.calls.Load())
+}
+
+func TestCachedSakeRepository_InvalidateByTag_DropsOnlyAffectedKeys(t *testing.T) {
+	inner := &countingSakeRepository{
+		sakes:  []entity.Sake{{ID: 1, Name: "獺祭"}},
+		paging: entity.Pagination{Total: 1, Limit: 20},
+	}
+	lruCache, err := cache.NewLRUCache(100)
+	require.NoError(t, err)
+
+	repo := NewCachedSakeRepository(inner, lruCache, time.Minute)
+
+	typeID1 := int32(1)
+	typeID2 := int32(2)
+	filter1 := repository.ListSakesFilter{TypeID: &typeID1, Limit: 20}
+	filter2 := repository.ListSakesFilter{TypeID: &typeID2, Limit: 20}
+
+	_, _, err = repo.List(context.Background(), filter1)
+	require.NoError(t, err)
+	_, _, err = repo.List(context.Background(), filter2)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), inner.calls.Load())
+
+	require.NoError(t, lruCache.DeleteByTag(context.Background(), "sake_type:1"))
+
+	// filter1はキャッシュが無効化されているのでinnerへ再度問い合わせる
+	_, _, err = repo.List(context.Background(), filter1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), inner.calls.Load())
+
+	// filter2はキャッシュが残っているのでinnerは呼ばれない
+	_, _, err = repo.List(context.Background(), filter2)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), inner.calls.Load())
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// sakeCursor はキーセットページネーションの不透明なカーソルが表す位置情報
+type sakeCursor struct {
+	ID        int32     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// encodeCursor はsakeCursorをbase64url文字列にエンコードする
+func encodeCursor(id int32, createdAt time.Time) (string, error) {
+	payload, err := json.Marshal(sakeCursor{ID: id, CreatedAt: createdAt})
+	if err != nil {
+		return "", fmt.Errorf("カーソルのエンコードに失敗しました: %w", err)
+	}
+	return base64.URLEncoding.EncodeToString(payload), nil
+}
+
+// decodeCursor はbase64url文字列をsakeCursorにデコードする
+func decodeCursor(cursor string) (sakeCursor, error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return sakeCursor{}, fmt.Errorf("カーソルのbase64デコードに失敗しました: %w", err)
+	}
+
+	var c sakeCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return sakeCursor{}, fmt.Errorf("カーソルのJSONデコードに失敗しました: %w", err)
+	}
+
+	return c, nil
+}
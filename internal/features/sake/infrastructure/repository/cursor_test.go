@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecodeCursor_RoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, 1, 15, 12, 30, 0, 123456789, time.UTC)
+
+	encoded, err := encodeCursor(42, createdAt)
+	assert.NoError(t, err)
+
+	decoded, err := decodeCursor(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(42), decoded.ID)
+	assert.True(t, createdAt.Equal(decoded.CreatedAt))
+}
+
+func TestDecodeCursor_InvalidBase64(t *testing.T) {
+	_, err := decodeCursor("not-valid-base64url!!!")
+
+	assert.Error(t, err)
+}
+
+func TestDecodeCursor_TamperedPayload(t *testing.T) {
+	encoded, err := encodeCursor(1, time.Now())
+	assert.NoError(t, err)
+
+	tampered := encoded[:len(encoded)-2] + "zz"
+
+	_, err = decodeCursor(tampered)
+	assert.Error(t, err)
+}
+
+func TestDecodeCursor_EmptyString(t *testing.T) {
+	_, err := decodeCursor("")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+)
+
+// ewkbSRIDFlag はEWKBのジオメトリ型にSRIDが付与されていることを示すフラグビット
+// (PostGISの拡張: https://libgeos.org/specifications/wkb/#extended-wkb)
+const ewkbSRIDFlag = 0x20000000
+
+// ewkbTypeMask はSRIDフラグ等の上位ビットを除いた基本ジオメトリ型を取り出すマスク
+const ewkbTypeMask = 0x000000FF
+
+// wkbTypePoint はWKB/EWKBにおけるPOINT型のジオメトリ型コード
+const wkbTypePoint = 1
+
+// extractCoordinates はbrewery.Position (GEOMETRY列) から緯度経度を抽出する
+// pgxはGEOMETRY型をバイト列、もしくはhex文字列として返すため両方を許容する
+func extractCoordinates(geomData interface{}) (*float64, *float64) {
+	raw, err := toEWKBBytes(geomData)
+	if err != nil {
+		return nil, nil
+	}
+
+	lat, lng, err := decodeEWKBPoint(raw)
+	if err != nil {
+		return nil, nil
+	}
+
+	return &lat, &lng
+}
+
+// toEWKBBytes はGEOMETRY列の値をEWKBの生バイト列に正規化する
+func toEWKBBytes(geomData interface{}) ([]byte, error) {
+	switch v := geomData.(type) {
+	case nil:
+		return nil, fmt.Errorf("ジオメトリデータがnilです")
+	case []byte:
+		return v, nil
+	case string:
+		decoded, err := hex.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("ジオメトリのhexデコードに失敗しました: %w", err)
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("未対応のジオメトリ型です: %T", geomData)
+	}
+}
+
+// decodeEWKBPoint はEWKBのPOINT型をデコードし、緯度・経度を返す
+// フォーマット: [byteOrder:1][geomType:4][SRID?:4][X:8][Y:8]
+func decodeEWKBPoint(data []byte) (lat float64, lng float64, err error) {
+	const headerSize = 5
+	if len(data) < headerSize {
+		return 0, 0, fmt.Errorf("EWKBヘッダーが不足しています: len=%d", len(data))
+	}
+
+	var order binary.ByteOrder
+	switch data[0] {
+	case 0:
+		order = binary.BigEndian
+	case 1:
+		order = binary.LittleEndian
+	default:
+		return 0, 0, fmt.Errorf("不正なバイトオーダーです: %d", data[0])
+	}
+
+	geomType := order.Uint32(data[1:5])
+	offset := headerSize
+
+	if geomType&ewkbSRIDFlag != 0 {
+		if len(data) < offset+4 {
+			return 0, 0, fmt.Errorf("SRIDフィールドが不足しています")
+		}
+		offset += 4
+	}
+
+	if geomType&ewkbTypeMask != wkbTypePoint {
+		return 0, 0, fmt.Errorf("POINT以外のジオメトリ型には対応していません: type=%d", geomType&ewkbTypeMask)
+	}
+
+	const pointSize = 16 // X, Y それぞれ8バイトのfloat64
+	if len(data) < offset+pointSize {
+		return 0, 0, fmt.Errorf("座標フィールドが不足しています")
+	}
+
+	x := math.Float64frombits(order.Uint64(data[offset : offset+8]))
+	y := math.Float64frombits(order.Uint64(data[offset+8 : offset+pointSize]))
+
+	// PostGISのPOINT(経度 緯度)の並びに合わせ、X=経度, Y=緯度として解釈する
+	return y, x, nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildEWKBPoint はテスト用にリトルエンディアンのEWKB POINTバイト列を組み立てる
+func buildEWKBPoint(t *testing.T, lng, lat float64, withSRID bool) []byte {
+	t.Helper()
+
+	buf := make([]byte, 0, 25)
+	buf = append(buf, 1) // リトルエンディアン
+
+	geomType := uint32(wkbTypePoint)
+	if withSRID {
+		geomType |= ewkbSRIDFlag
+	}
+	typeBytes := make([]byte, 4)
+	binary.LittleEndian.PutUint32(typeBytes, geomType)
+	buf = append(buf, typeBytes...)
+
+	if withSRID {
+		sridBytes := make([]byte, 4)
+		binary.LittleEndian.PutUint32(sridBytes, 4326)
+		buf = append(buf, sridBytes...)
+	}
+
+	xBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(xBytes, math.Float64bits(lng))
+	buf = append(buf, xBytes...)
+
+	yBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(yBytes, math.Float64bits(lat))
+	buf = append(buf, yBytes...)
+
+	return buf
+}
+
+func TestDecodeEWKBPoint_WithSRID(t *testing.T) {
+	raw := buildEWKBPoint(t, 131.4714, 34.1658, true)
+
+	lat, lng, err := decodeEWKBPoint(raw)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 34.1658, lat, 0.0001)
+	assert.InDelta(t, 131.4714, lng, 0.0001)
+}
+
+func TestDecodeEWKBPoint_WithoutSRID(t *testing.T) {
+	raw := buildEWKBPoint(t, 139.6917, 35.6895, false)
+
+	lat, lng, err := decodeEWKBPoint(raw)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 35.6895, lat, 0.0001)
+	assert.InDelta(t, 139.6917, lng, 0.0001)
+}
+
+func TestDecodeEWKBPoint_InvalidByteOrder(t *testing.T) {
+	raw := buildEWKBPoint(t, 0, 0, false)
+	raw[0] = 0xFF
+
+	_, _, err := decodeEWKBPoint(raw)
+
+	assert.Error(t, err)
+}
+
+func TestDecodeEWKBPoint_NonPointGeometry(t *testing.T) {
+	raw := buildEWKBPoint(t, 0, 0, false)
+	binary.LittleEndian.PutUint32(raw[1:5], 2) // LINESTRING
+
+	_, _, err := decodeEWKBPoint(raw)
+
+	assert.Error(t, err)
+}
+
+func TestDecodeEWKBPoint_TooShort(t *testing.T) {
+	_, _, err := decodeEWKBPoint([]byte{1, 2, 3})
+
+	assert.Error(t, err)
+}
+
+func TestExtractCoordinates_FromBytes(t *testing.T) {
+	raw := buildEWKBPoint(t, 131.4714, 34.1658, true)
+
+	lat, lng := extractCoordinates(raw)
+
+	if assert.NotNil(t, lat) && assert.NotNil(t, lng) {
+		assert.InDelta(t, 34.1658, *lat, 0.0001)
+		assert.InDelta(t, 131.4714, *lng, 0.0001)
+	}
+}
+
+func TestExtractCoordinates_FromHexString(t *testing.T) {
+	raw := buildEWKBPoint(t, 131.4714, 34.1658, true)
+	hexStr := bytesToHex(raw)
+
+	lat, lng := extractCoordinates(hexStr)
+
+	if assert.NotNil(t, lat) && assert.NotNil(t, lng) {
+		assert.InDelta(t, 34.1658, *lat, 0.0001)
+		assert.InDelta(t, 131.4714, *lng, 0.0001)
+	}
+}
+
+func TestExtractCoordinates_Nil(t *testing.T) {
+	lat, lng := extractCoordinates(nil)
+
+	assert.Nil(t, lat)
+	assert.Nil(t, lng)
+}
+
+func TestExtractCoordinates_UnsupportedType(t *testing.T) {
+	lat, lng := extractCoordinates(12345)
+
+	assert.Nil(t, lat)
+	assert.Nil(t, lng)
+}
+
+func bytesToHex(b []byte) string {
+	const hexDigits = "0123456789abcdef"
+	out := make([]byte, len(b)*2)
+	for i, c := range b {
+		out[i*2] = hexDigits[c>>4]
+		out[i*2+1] = hexDigits[c&0x0f]
+	}
+	return string(out)
+}
@@ -31,33 +31,47 @@ func NewSakeRepository(db *pgxpool.Pool) repository.SakeRepository {
 
 // List 酒一覧を取得
 func (r *sakeRepositoryImpl) List(ctx context.Context, filter repository.ListSakesFilter) ([]entity.Sake, entity.Pagination, error) {
-	defer logger.TraceMethodAuto(ctx, filter)()
+	ctx, done := logger.TraceMethodAuto(ctx, filter)
+	defer done()
+
+	if filter.Cursor != nil {
+		return r.listByCursor(ctx, filter)
+	}
 
 	// カウント取得
 	countParams := sqlc.CountSakesParams{
 		TypeID:    filter.TypeID,
 		BreweryID: filter.BreweryID,
 	}
+	if filter.NearBrewery != nil {
+		countParams.Lat = &filter.NearBrewery.Latitude
+		countParams.Lng = &filter.NearBrewery.Longitude
+		countParams.RadiusM = &filter.NearBrewery.RadiusMeters
+	}
 	total, err := r.queries.CountSakes(ctx, countParams)
 	if err != nil {
-		logger.LogDatabaseError(ctx, "SELECT", "sakes", err, map[string]interface{}{
-			"filter": filter,
-		})
+		logger.LogDatabaseError(ctx, "SELECT", "sakes", err, logger.Any("filter", filter))
 		return nil, entity.Pagination{}, apperror.DatabaseError("酒の件数取得に失敗しました", err)
 	}
 
 	// リスト取得
+	// NearBreweryが指定されている場合、ListSakesクエリ側で
+	// ST_DWithin(position::geography, ST_MakePoint($lng,$lat)::geography, $radius_m) により絞り込み、
+	// 距離の昇順でソートする
 	listParams := sqlc.ListSakesParams{
 		Limit:     filter.Limit,
 		Offset:    filter.Offset,
 		TypeID:    filter.TypeID,
 		BreweryID: filter.BreweryID,
 	}
+	if filter.NearBrewery != nil {
+		listParams.Lat = &filter.NearBrewery.Latitude
+		listParams.Lng = &filter.NearBrewery.Longitude
+		listParams.RadiusM = &filter.NearBrewery.RadiusMeters
+	}
 	sakeRows, err := r.queries.ListSakes(ctx, listParams)
 	if err != nil {
-		logger.LogDatabaseError(ctx, "SELECT", "sakes", err, map[string]interface{}{
-			"filter": filter,
-		})
+		logger.LogDatabaseError(ctx, "SELECT", "sakes", err, logger.Any("filter", filter))
 		return nil, entity.Pagination{}, apperror.DatabaseError("酒一覧の取得に失敗しました", err)
 	}
 
@@ -80,6 +94,65 @@ func (r *sakeRepositoryImpl) List(ctx context.Context, filter repository.ListSak
 	return sakes, pagination, nil
 }
 
+// listByCursor はカーソルページネーションで酒一覧を取得する
+// クエリは `WHERE (created_at, id) < ($cursor_ts, $cursor_id) ORDER BY created_at DESC, id DESC LIMIT $limit`
+// で次ページの有無を判定するため、limit+1件取得して末尾の余剰行を切り落とす
+func (r *sakeRepositoryImpl) listByCursor(ctx context.Context, filter repository.ListSakesFilter) ([]entity.Sake, entity.Pagination, error) {
+	cursor, err := decodeCursor(*filter.Cursor)
+	if err != nil {
+		logger.LogValidationError(ctx, "cursor", *filter.Cursor, err.Error())
+		return nil, entity.Pagination{}, apperror.BadRequestError("カーソルが不正です")
+	}
+
+	params := sqlc.ListSakesByCursorParams{
+		CursorCreatedAt: cursor.CreatedAt,
+		CursorID:        cursor.ID,
+		Limit:           filter.Limit + 1,
+		TypeID:          filter.TypeID,
+		BreweryID:       filter.BreweryID,
+	}
+	if filter.NearBrewery != nil {
+		params.Lat = &filter.NearBrewery.Latitude
+		params.Lng = &filter.NearBrewery.Longitude
+		params.RadiusM = &filter.NearBrewery.RadiusMeters
+	}
+
+	sakeRows, err := r.queries.ListSakesByCursor(ctx, params)
+	if err != nil {
+		logger.LogDatabaseError(ctx, "SELECT", "sakes", err, logger.Any("filter", filter))
+		return nil, entity.Pagination{}, apperror.DatabaseError("酒一覧の取得に失敗しました", err)
+	}
+
+	hasMore := len(sakeRows) > int(filter.Limit)
+	if hasMore {
+		sakeRows = sakeRows[:filter.Limit]
+	}
+
+	sakes := make([]entity.Sake, 0, len(sakeRows))
+	for _, row := range sakeRows {
+		sake, err := r.toSakeEntity(ctx, row)
+		if err != nil {
+			return nil, entity.Pagination{}, err
+		}
+		sakes = append(sakes, *sake)
+	}
+
+	pagination := entity.Pagination{
+		Limit:   filter.Limit,
+		HasMore: hasMore,
+	}
+	if hasMore && len(sakes) > 0 {
+		last := sakes[len(sakes)-1]
+		nextCursor, err := encodeCursor(last.ID, last.CreatedAt)
+		if err != nil {
+			return nil, entity.Pagination{}, apperror.InternalServerError("カーソルの生成に失敗しました").WithErr(err)
+		}
+		pagination.NextCursor = &nextCursor
+	}
+
+	return sakes, pagination, nil
+}
+
 // toSakeEntity sqlcモデルからDomainエンティティに変換
 func (r *sakeRepositoryImpl) toSakeEntity(ctx context.Context, row sqlc.Sake) (*entity.Sake, error) {
 	// 酒の種類取得
@@ -88,9 +161,7 @@ func (r *sakeRepositoryImpl) toSakeEntity(ctx context.Context, row sqlc.Sake) (*
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperror.NotFoundError("酒の種類が見つかりません")
 		}
-		logger.LogDatabaseError(ctx, "SELECT", "sake_types", err, map[string]interface{}{
-			"type_id": row.TypeID,
-		})
+		logger.LogDatabaseError(ctx, "SELECT", "sake_types", err, logger.Int32("type_id", row.TypeID))
 		return nil, apperror.DatabaseError("酒の種類取得に失敗しました", err)
 	}
 
@@ -100,18 +171,14 @@ func (r *sakeRepositoryImpl) toSakeEntity(ctx context.Context, row sqlc.Sake) (*
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperror.NotFoundError("酒造が見つかりません")
 		}
-		logger.LogDatabaseError(ctx, "SELECT", "breweries", err, map[string]interface{}{
-			"brewery_id": row.BreweryID,
-		})
+		logger.LogDatabaseError(ctx, "SELECT", "breweries", err, logger.Int32("brewery_id", row.BreweryID))
 		return nil, apperror.DatabaseError("酒造取得に失敗しました", err)
 	}
 
 	// 飲み方取得
 	drinkStyleRows, err := r.queries.GetDrinkStylesBySakeID(ctx, row.ID)
 	if err != nil {
-		logger.LogDatabaseError(ctx, "SELECT", "drink_styles", err, map[string]interface{}{
-			"sake_id": row.ID,
-		})
+		logger.LogDatabaseError(ctx, "SELECT", "drink_styles", err, logger.Int32("sake_id", row.ID))
 		return nil, apperror.DatabaseError("飲み方取得に失敗しました", err)
 	}
 
@@ -151,14 +218,6 @@ func (r *sakeRepositoryImpl) toSakeEntity(ctx context.Context, row sqlc.Sake) (*
 	}, nil
 }
 
-// extractCoordinates GEOMETRY型から緯度経度を抽出
-func extractCoordinates(geomData interface{}) (*float64, *float64) {
-	// PostGISのGEOMETRY型はinterface{}として返されるため
-	// 現時点ではGEOMETRY型のパースは未実装(座標はnilを返す)
-	// 将来的にはtwpayne/go-geomを使用して座標を抽出する
-	return nil, nil
-}
-
 // convertNumericToFloat32 pgtype.NumericをFloat32に変換
 func convertNumericToFloat32(n pgtype.Numeric) float32 {
 	f64, err := n.Float64Value()
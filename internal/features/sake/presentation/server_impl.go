@@ -10,21 +10,29 @@ import (
 	"github.com/sake-kasu/sake-hack-backend/internal/apperror"
 	"github.com/sake-kasu/sake-hack-backend/internal/features/sake/application/usecase"
 	"github.com/sake-kasu/sake-hack-backend/internal/features/sake/domain/entity"
+	"github.com/sake-kasu/sake-hack-backend/internal/features/sake/domain/repository"
 	"github.com/sake-kasu/sake-hack-backend/internal/logger"
+	"github.com/sake-kasu/sake-hack-backend/internal/requestparser"
 )
 
+// maxNearBreweryRadiusMeters は酒造位置検索で許容する半径の上限(500km)
+const maxNearBreweryRadiusMeters = 500_000
+
 // validate はgo-playground/validatorのインスタンス
 var validate = validator.New()
 
 // SakeServerImpl 酒関連のServerInterface実装
 type SakeServerImpl struct {
 	listSakesUsecase usecase.ListSakesUsecaseInterface
+	parsers          *requestparser.Registry
 }
 
 // NewSakeServerImpl コンストラクタ
-func NewSakeServerImpl(listSakesUsecase usecase.ListSakesUsecaseInterface) *SakeServerImpl {
+// parsersはContent-Typeに応じた入出力方式(JSON/フォームエンコード)を解決するためのレジストリ
+func NewSakeServerImpl(listSakesUsecase usecase.ListSakesUsecaseInterface, parsers *requestparser.Registry) *SakeServerImpl {
 	return &SakeServerImpl{
 		listSakesUsecase: listSakesUsecase,
+		parsers:          parsers,
 	}
 }
 
@@ -32,11 +40,14 @@ func NewSakeServerImpl(listSakesUsecase usecase.ListSakesUsecaseInterface) *Sake
 // (GET /sakes)
 func (s *SakeServerImpl) ListSakes(c *gin.Context, params generated.ListSakesParams) {
 	ctx := c.Request.Context()
-	defer logger.TraceMethodAuto(ctx, params)()
+	ctx, done := logger.TraceMethodAuto(ctx, params)
+	defer done()
+
+	parser := s.parsers.ForRequest(c)
 
 	// バリデーション
 	if err := validateListSakesParams(params); err != nil {
-		handleError(c, err)
+		handleError(c, parser, err)
 		return
 	}
 
@@ -53,19 +64,22 @@ func (s *SakeServerImpl) ListSakes(c *gin.Context, params generated.ListSakesPar
 
 	// Usecase実行
 	output, err := s.listSakesUsecase.Execute(ctx, usecase.ListSakesInput{
-		TypeID:    params.TypeId,
-		BreweryID: params.BreweryId,
-		Offset:    offset,
-		Limit:     limit,
+		TypeID:      params.TypeId,
+		BreweryID:   params.BreweryId,
+		NearBrewery: toNearBreweryFilter(params),
+		Cursor:      params.Cursor,
+		Offset:      offset,
+		Limit:       limit,
+		BypassCache: c.Query("bypass_cache") == "true",
 	})
 	if err != nil {
-		handleError(c, err)
+		handleError(c, parser, err)
 		return
 	}
 
 	// レスポンス変換
 	response := toListSakesResponse(output)
-	c.JSON(http.StatusOK, response)
+	_ = parser.WriteOutput(c, http.StatusOK, response)
 }
 
 // validateListSakesParams はListSakesのパラメータをバリデーションする
@@ -98,12 +112,49 @@ func validateListSakesParams(params generated.ListSakesParams) error {
 		}
 	}
 
+	// 酒造位置検索(lat/lng/radius_m)は3つ揃って初めて有効になる
+	if params.Lat != nil {
+		if err := validate.Var(*params.Lat, "min=-90,max=90"); err != nil {
+			verr = verr.AddField("lat", "緯度は-90以上90以下である必要があります")
+		}
+	}
+
+	if params.Lng != nil {
+		if err := validate.Var(*params.Lng, "min=-180,max=180"); err != nil {
+			verr = verr.AddField("lng", "経度は-180以上180以下である必要があります")
+		}
+	}
+
+	if params.RadiusM != nil {
+		if *params.RadiusM <= 0 || *params.RadiusM > maxNearBreweryRadiusMeters {
+			verr = verr.AddField("radius_m", "半径は0より大きく500000以下である必要があります")
+		}
+	}
+
+	// cursorとoffsetは併用不可(キーセットページネーションとオフセットページネーションは排他)
+	if params.Cursor != nil && params.Offset != nil {
+		verr = verr.AddField("cursor", "cursorとoffsetは同時に指定できません")
+	}
+
 	if verr.HasErrors() {
 		return verr
 	}
 	return nil
 }
 
+// toNearBreweryFilter lat/lng/radius_mがすべて指定されている場合のみNearBreweryFilterを構築する
+func toNearBreweryFilter(params generated.ListSakesParams) *repository.NearBreweryFilter {
+	if params.Lat == nil || params.Lng == nil || params.RadiusM == nil {
+		return nil
+	}
+
+	return &repository.NearBreweryFilter{
+		Latitude:     float64(*params.Lat),
+		Longitude:    float64(*params.Lng),
+		RadiusMeters: float64(*params.RadiusM),
+	}
+}
+
 // 【参考】手動バリデーションの例（サンプルとして残す）
 //
 // 手動バリデーションの実装例:
@@ -151,9 +202,11 @@ func toListSakesResponse(output *usecase.ListSakesOutput) generated.ListSakesRes
 	}
 
 	meta := generated.SakeListMeta{
-		Total:  output.Pagination.Total,
-		Offset: output.Pagination.Offset,
-		Limit:  output.Pagination.Limit,
+		Total:      output.Pagination.Total,
+		Offset:     output.Pagination.Offset,
+		Limit:      output.Pagination.Limit,
+		NextCursor: output.Pagination.NextCursor,
+		HasMore:    output.Pagination.HasMore,
 	}
 
 	return generated.ListSakesResponse{
@@ -199,7 +252,9 @@ func toSakeResponse(sake entity.Sake) generated.Sake {
 }
 
 // handleError エラーをHTTPレスポンスに変換
-func handleError(c *gin.Context, err error) {
+func handleError(c *gin.Context, parser requestparser.Parser, err error) {
+	ctx := c.Request.Context()
+
 	// ValidationErrorのチェック
 	if valErr, ok := err.(*apperror.ValidationError); ok {
 		// ValidationErrorの場合、Fieldsも含めてレスポンス
@@ -210,7 +265,7 @@ func handleError(c *gin.Context, err error) {
 				Message: field + ": " + msg,
 			})
 		}
-		c.JSON(valErr.Status, generated.ErrorResponse{
+		_ = parser.WriteOutput(c, valErr.Status, generated.ErrorResponse{
 			Data:   nil,
 			Errors: &errors,
 		})
@@ -220,27 +275,32 @@ func handleError(c *gin.Context, err error) {
 	// 通常のAppErrorのチェック
 	var appErr *apperror.AppError
 	if appErr = apperror.As(err); appErr != nil {
+		if appErr.Status >= http.StatusInternalServerError {
+			logger.Error(ctx, "リクエスト処理中にエラーが発生しました", logger.Err(err))
+		}
 		errors := []generated.APIError{
 			{
 				Code:    appErr.Code,
 				Message: appErr.Message,
 			},
 		}
-		c.JSON(appErr.Status, generated.ErrorResponse{
+		_ = parser.WriteOutput(c, appErr.Status, generated.ErrorResponse{
 			Data:   nil,
 			Errors: &errors,
 		})
 		return
 	}
 
-	// 予期しないエラー
+	// 予期しないエラー(スタックトレース付きでログに記録し、err.Error()自体はレスポンスに含めない)
+	logger.Error(ctx, "予期しないエラーが発生しました", logger.Err(err))
+
 	errors := []generated.APIError{
 		{
 			Code:    apperror.ErrCodeInternalError,
 			Message: "内部エラーが発生しました",
 		},
 	}
-	c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+	_ = parser.WriteOutput(c, http.StatusInternalServerError, generated.ErrorResponse{
 		Data:   nil,
 		Errors: &errors,
 	})
@@ -12,11 +12,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/sake-kasu/sake-hack-backend/api/generated"
 	"github.com/sake-kasu/sake-hack-backend/internal/apperror"
 	"github.com/sake-kasu/sake-hack-backend/internal/features/sake/application/usecase"
 	"github.com/sake-kasu/sake-hack-backend/internal/features/sake/domain/entity"
+	"github.com/sake-kasu/sake-hack-backend/internal/requestparser"
 )
 
 // MockListSakesUsecase はListSakesUsecaseのモック
@@ -79,7 +81,7 @@ func TestListSakes_Success(t *testing.T) {
 	})).Return(expectedOutput, nil)
 
 	// テスト対象
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	// Ginテストモード
 	gin.SetMode(gin.TestMode)
@@ -113,7 +115,7 @@ func TestListSakes_Success(t *testing.T) {
 
 func TestListSakes_ValidationError_OffsetLessThan0(t *testing.T) {
 	mockUsecase := new(MockListSakesUsecase)
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -138,7 +140,7 @@ func TestListSakes_ValidationError_OffsetLessThan0(t *testing.T) {
 
 func TestListSakes_ValidationError_LimitLessThan1(t *testing.T) {
 	mockUsecase := new(MockListSakesUsecase)
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -155,7 +157,7 @@ func TestListSakes_ValidationError_LimitLessThan1(t *testing.T) {
 
 func TestListSakes_ValidationError_LimitGreaterThan100(t *testing.T) {
 	mockUsecase := new(MockListSakesUsecase)
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -172,7 +174,7 @@ func TestListSakes_ValidationError_LimitGreaterThan100(t *testing.T) {
 
 func TestListSakes_ValidationError_TypeIdLessThan1(t *testing.T) {
 	mockUsecase := new(MockListSakesUsecase)
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -189,7 +191,7 @@ func TestListSakes_ValidationError_TypeIdLessThan1(t *testing.T) {
 
 func TestListSakes_ValidationError_BreweryIdLessThan1(t *testing.T) {
 	mockUsecase := new(MockListSakesUsecase)
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -211,7 +213,7 @@ func TestListSakes_UsecaseError_Database(t *testing.T) {
 		apperror.DatabaseError("データベースエラーが発生しました", fmt.Errorf("connection error")),
 	)
 
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -243,7 +245,7 @@ func TestListSakes_DefaultValues(t *testing.T) {
 		Pagination: entity.Pagination{Total: 0, Offset: 0, Limit: 20},
 	}, nil)
 
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -267,7 +269,7 @@ func TestListSakes_BoundaryValue_OffsetMinimum(t *testing.T) {
 		Pagination: entity.Pagination{Total: 0, Offset: 0, Limit: 20},
 	}, nil)
 
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -291,7 +293,7 @@ func TestListSakes_BoundaryValue_LimitMinimum(t *testing.T) {
 		Pagination: entity.Pagination{Total: 0, Offset: 0, Limit: 1},
 	}, nil)
 
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -315,7 +317,7 @@ func TestListSakes_BoundaryValue_LimitMaximum(t *testing.T) {
 		Pagination: entity.Pagination{Total: 0, Offset: 0, Limit: 100},
 	}, nil)
 
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -339,7 +341,7 @@ func TestListSakes_BoundaryValue_TypeIdMinimum(t *testing.T) {
 		Pagination: entity.Pagination{Total: 0, Offset: 0, Limit: 20},
 	}, nil)
 
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -363,7 +365,7 @@ func TestListSakes_BoundaryValue_BreweryIdMinimum(t *testing.T) {
 		Pagination: entity.Pagination{Total: 0, Offset: 0, Limit: 20},
 	}, nil)
 
-	server := NewSakeServerImpl(mockUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -448,15 +450,159 @@ func TestValidateListSakesParams_MultipleErrors(t *testing.T) {
 	assert.Contains(t, valErr.Fields, "limit")
 }
 
+func TestListSakes_ValidationError_LatOutOfRange(t *testing.T) {
+	mockUsecase := new(MockListSakesUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	generated.RegisterHandlers(router, server)
+
+	req := httptest.NewRequest(http.MethodGet, "/sakes?lat=91&lng=135&radius_m=10000", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "Execute")
+}
+
+func TestListSakes_ValidationError_LngOutOfRange(t *testing.T) {
+	mockUsecase := new(MockListSakesUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	generated.RegisterHandlers(router, server)
+
+	req := httptest.NewRequest(http.MethodGet, "/sakes?lat=35&lng=181&radius_m=10000", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "Execute")
+}
+
+func TestListSakes_ValidationError_RadiusMZeroOrLess(t *testing.T) {
+	mockUsecase := new(MockListSakesUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	generated.RegisterHandlers(router, server)
+
+	req := httptest.NewRequest(http.MethodGet, "/sakes?lat=35&lng=135&radius_m=0", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "Execute")
+}
+
+func TestListSakes_ValidationError_RadiusMExceedsMax(t *testing.T) {
+	mockUsecase := new(MockListSakesUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	generated.RegisterHandlers(router, server)
+
+	req := httptest.NewRequest(http.MethodGet, "/sakes?lat=35&lng=135&radius_m=500001", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertNotCalled(t, "Execute")
+}
+
+func TestListSakes_NearBrewery_PassedToUsecase(t *testing.T) {
+	mockUsecase := new(MockListSakesUsecase)
+	mockUsecase.On("Execute", mock.Anything, mock.MatchedBy(func(input usecase.ListSakesInput) bool {
+		return input.NearBrewery != nil &&
+			input.NearBrewery.Latitude == 34.1658 &&
+			input.NearBrewery.Longitude == 131.4714 &&
+			input.NearBrewery.RadiusMeters == 10000
+	})).Return(&usecase.ListSakesOutput{
+		Sakes:      []entity.Sake{},
+		Pagination: entity.Pagination{Total: 0, Offset: 0, Limit: 20},
+	}, nil)
+
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	generated.RegisterHandlers(router, server)
+
+	req := httptest.NewRequest(http.MethodGet, "/sakes?lat=34.1658&lng=131.4714&radius_m=10000", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestListSakes_ValidationError_CursorAndOffsetConflict(t *testing.T) {
+	mockUsecase := new(MockListSakesUsecase)
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	generated.RegisterHandlers(router, server)
+
+	req := httptest.NewRequest(http.MethodGet, "/sakes?cursor=abc&offset=10", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response generated.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.NotNil(t, response.Errors)
+	assert.Contains(t, (*response.Errors)[0].Message, "cursor")
+
+	mockUsecase.AssertNotCalled(t, "Execute")
+}
+
+func TestListSakes_Cursor_PassedToUsecase(t *testing.T) {
+	mockUsecase := new(MockListSakesUsecase)
+	mockUsecase.On("Execute", mock.Anything, mock.MatchedBy(func(input usecase.ListSakesInput) bool {
+		return input.Cursor != nil && *input.Cursor == "eyJpZCI6MX0"
+	})).Return(&usecase.ListSakesOutput{
+		Sakes:      []entity.Sake{},
+		Pagination: entity.Pagination{Limit: 20},
+	}, nil)
+
+	server := NewSakeServerImpl(mockUsecase, requestparser.NewDefaultRegistry())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	generated.RegisterHandlers(router, server)
+
+	req := httptest.NewRequest(http.MethodGet, "/sakes?cursor=eyJpZCI6MX0", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
 func TestHandleError_ValidationError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/sakes", nil)
 
 	verr := apperror.NewValidationError("バリデーションエラー")
 	verr = verr.AddField("field1", "エラーメッセージ")
 
-	handleError(c, verr)
+	handleError(c, requestparser.NewJSONParser(), verr)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
@@ -473,10 +619,11 @@ func TestHandleError_AppError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/sakes", nil)
 
 	appErr := apperror.NotFoundError("見つかりません")
 
-	handleError(c, appErr)
+	handleError(c, requestparser.NewJSONParser(), appErr)
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
 
@@ -492,11 +639,12 @@ func TestHandleError_UnexpectedError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/sakes", nil)
 
 	// 予期しないエラー(AppErrorでもValidationErrorでもない)
 	unexpectedErr := fmt.Errorf("unexpected error")
 
-	handleError(c, unexpectedErr)
+	handleError(c, requestparser.NewJSONParser(), unexpectedErr)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 
@@ -508,3 +656,62 @@ func TestHandleError_UnexpectedError(t *testing.T) {
 	assert.Equal(t, apperror.ErrCodeInternalError, (*response.Errors)[0].Code)
 	assert.Equal(t, "内部エラーが発生しました", (*response.Errors)[0].Message)
 }
+
+func TestListSakes_JSONAndFormParsers_ProduceIdenticalOutput(t *testing.T) {
+	now := time.Now()
+	expectedOutput := &usecase.ListSakesOutput{
+		Sakes: []entity.Sake{
+			{
+				ID:   1,
+				Name: "獺祭 純米大吟醸50",
+				Type: entity.SakeType{ID: 1, Name: "純米大吟醸"},
+				Brewery: entity.Brewery{
+					ID:   1,
+					Name: "旭酒造",
+				},
+				ABV:       16.0,
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+		},
+		Pagination: entity.Pagination{
+			Total:  1,
+			Offset: 0,
+			Limit:  20,
+		},
+	}
+
+	runWithRegistry := func(registry *requestparser.Registry) []byte {
+		mockUsecase := new(MockListSakesUsecase)
+		mockUsecase.On("Execute", mock.Anything, mock.Anything).Return(expectedOutput, nil)
+
+		server := NewSakeServerImpl(mockUsecase, registry)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		generated.RegisterHandlers(router, server)
+
+		req := httptest.NewRequest(http.MethodGet, "/sakes", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		return w.Body.Bytes()
+	}
+
+	jsonRegistry := requestparser.NewDefaultRegistry()
+	formRegistry := requestparser.NewRegistry(
+		map[string]requestparser.Parser{"form": requestparser.NewFormParser()},
+		nil,
+		"form",
+	)
+
+	jsonBody := runWithRegistry(jsonRegistry)
+	formBody := runWithRegistry(formRegistry)
+
+	var jsonResponse, formResponse generated.ListSakesResponse
+	require.NoError(t, json.Unmarshal(jsonBody, &jsonResponse))
+	require.NoError(t, json.Unmarshal(formBody, &formResponse))
+
+	assert.Equal(t, jsonResponse, formResponse)
+}
@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Field はロガーの呼び出し元をgo.uber.org/zapに直接依存させないための構造化フィールド抽象
+type Field interface {
+	Key() string
+	Value() interface{}
+}
+
+// field はFieldの標準実装。zapへ渡すためのzap.Fieldを内部に保持する
+type field struct {
+	key string
+	val interface{}
+	zf  zap.Field
+}
+
+func (f field) Key() string        { return f.key }
+func (f field) Value() interface{} { return f.val }
+
+// String は文字列フィールドを生成する
+func String(key, value string) Field {
+	return field{key: key, val: value, zf: zap.String(key, value)}
+}
+
+// Int はint型のフィールドを生成する
+func Int(key string, value int) Field {
+	return field{key: key, val: value, zf: zap.Int(key, value)}
+}
+
+// Int32 はint32型のフィールドを生成する
+func Int32(key string, value int32) Field {
+	return field{key: key, val: value, zf: zap.Int32(key, value)}
+}
+
+// Int64 はint64型のフィールドを生成する
+func Int64(key string, value int64) Field {
+	return field{key: key, val: value, zf: zap.Int64(key, value)}
+}
+
+// Float64 はfloat64型のフィールドを生成する
+func Float64(key string, value float64) Field {
+	return field{key: key, val: value, zf: zap.Float64(key, value)}
+}
+
+// Bool はbool型のフィールドを生成する
+func Bool(key string, value bool) Field {
+	return field{key: key, val: value, zf: zap.Bool(key, value)}
+}
+
+// Duration はtime.Duration型のフィールドを生成する
+func Duration(key string, value time.Duration) Field {
+	return field{key: key, val: value, zf: zap.Duration(key, value)}
+}
+
+// Any は任意の値をフィールドとして生成する(型が定まっていない値向け)
+func Any(key string, value interface{}) Field {
+	return field{key: key, val: value, zf: zap.Any(key, value)}
+}
+
+// Err はerror型を "error" キーのフィールドとして生成する
+func Err(err error) Field {
+	return field{key: "error", val: err, zf: zap.Error(err)}
+}
+
+// Adapt は既存のzap.Field呼び出し箇所を段階的に移行できるよう、zap.FieldをFieldへ変換する
+func Adapt(zf zap.Field) Field {
+	return field{key: zf.Key, val: zf.Interface, zf: zf}
+}
+
+// toZapFields はFieldのスライスをzap.Fieldのスライスへ変換する
+func toZapFields(fields []Field) []zap.Field {
+	zapFields := make([]zap.Field, 0, len(fields))
+	for _, f := range fields {
+		if ff, ok := f.(field); ok {
+			zapFields = append(zapFields, ff.zf)
+			continue
+		}
+		// 独自実装のFieldはzap.Anyへフォールバックする
+		zapFields = append(zapFields, zap.Any(f.Key(), f.Value()))
+	}
+	return zapFields
+}
@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileConfig はローカルファイルへのログローテーション設定
+type FileConfig struct {
+	Enable     bool
+	Path       string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	Compress   bool
+}
+
+// newFileWriteSyncer はローテーション付きのファイルWriteSyncerを作成する
+func newFileWriteSyncer(cfg FileConfig) zapcore.WriteSyncer {
+	return zapcore.AddSync(&lumberjack.Logger{
+		Filename:   cfg.Path,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAgeDays,
+		Compress:   cfg.Compress,
+	})
+}
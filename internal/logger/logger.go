@@ -3,16 +3,23 @@ package logger
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"time"
 
+	"github.com/sake-kasu/sake-hack-backend/internal/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 var globalLogger *zap.Logger
 
+// atomicLevel は全コアで共有するログレベル。SetLevelでプロセス再起動なしに変更できる
+var atomicLevel = zap.NewAtomicLevel()
+
 // コンテキストキー型
 type contextKey string
 
@@ -23,43 +30,91 @@ const (
 	UserIDKey    contextKey = "user_id"
 )
 
+// LogConfig はロガーの初期化設定。標準出力に加え、ファイル・Lokiへのファンアウトを制御する
+type LogConfig struct {
+	Level  string
+	Format string
+	File   FileConfig
+	Loki   LokiConfig
+	// StackDepth はエラーログに付与するスタックトレースの最大フレーム数(0の場合はデフォルト値を使用)
+	StackDepth int
+	// StackSkipPatterns はスタックトレースから除外する関数名のパターン(部分一致)
+	StackSkipPatterns []string
+}
+
+// lokiCoreCloser はSync時にLokiバッチャーを停止できるコアを保持する(Sync経由でflushされる)
+var lokiCoreCloser io.Closer
+
 // Init はロガーを初期化する
 func Init(level, format string) error {
-	var config zap.Config
+	return InitWithConfig(LogConfig{Level: level, Format: format})
+}
+
+// InitWithConfig は複数シンク(stdout/ファイル/Loki)へファンアウトするロガーを初期化する
+func InitWithConfig(cfg LogConfig) error {
+	ConfigureStackCapture(cfg.StackDepth, cfg.StackSkipPatterns)
+
+	atomicLevel.SetLevel(parseLevel(cfg.Level))
 
-	if format == "json" {
-		config = zap.NewProductionConfig()
+	var encoderConfig zapcore.EncoderConfig
+	var encoder zapcore.Encoder
+	if cfg.Format == "json" {
+		encoderConfig = zap.NewProductionEncoderConfig()
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
 	} else {
-		config = zap.NewDevelopmentConfig()
-		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		encoderConfig = zap.NewDevelopmentEncoderConfig()
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
 	}
 
-	// ログレベル設定
-	switch level {
-	case "debug":
-		config.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
-	case "info":
-		config.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
-	case "warn":
-		config.Level = zap.NewAtomicLevelAt(zapcore.WarnLevel)
-	case "error":
-		config.Level = zap.NewAtomicLevelAt(zapcore.ErrorLevel)
-	default:
-		config.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	// 各コアはatomicLevelを共有するため、SetLevelで全コアのレベルを一括変更できる
+	cores := []zapcore.Core{
+		zapcore.NewCore(encoder, zapcore.Lock(os.Stdout), atomicLevel),
 	}
 
-	var err error
-	globalLogger, err = config.Build(
+	if cfg.File.Enable {
+		cores = append(cores, zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), newFileWriteSyncer(cfg.File), atomicLevel))
+	}
+
+	if cfg.Loki.Enable {
+		lokiCore := NewLokiCore(cfg.Loki, atomicLevel)
+		cores = append(cores, lokiCore)
+		if closer, ok := lokiCore.(io.Closer); ok {
+			lokiCoreCloser = closer
+		}
+	}
+
+	core := metrics.WrapCore(zapcore.NewTee(cores...))
+
+	globalLogger = zap.New(core,
 		zap.AddCaller(),
 		zap.AddCallerSkip(1),
 	)
-	if err != nil {
-		return fmt.Errorf("failed to initialize logger: %w", err)
-	}
 
 	return nil
 }
 
+// SetLevel はログレベルをプロセス再起動なしに変更する(config.Watcher経由のホットリロードで使用)
+func SetLevel(level string) {
+	atomicLevel.SetLevel(parseLevel(level))
+}
+
+// parseLevel はログレベル文字列をzapcore.Levelに変換する。不明な値はInfoにフォールバックする
+func parseLevel(level string) zapcore.Level {
+	switch level {
+	case "debug":
+		return zapcore.DebugLevel
+	case "info":
+		return zapcore.InfoLevel
+	case "warn":
+		return zapcore.WarnLevel
+	case "error":
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
 // Get はグローバルロガーを取得する
 func Get() *zap.Logger {
 	if globalLogger == nil {
@@ -74,6 +129,9 @@ func Sync() {
 	if globalLogger != nil {
 		_ = globalLogger.Sync()
 	}
+	if lokiCoreCloser != nil {
+		_ = lokiCoreCloser.Close()
+	}
 }
 
 // WithContext はコンテキストからロガーを取得する
@@ -85,7 +143,14 @@ func WithContext(ctx context.Context) *zap.Logger {
 		logger = logger.With(zap.String("request_id", requestID))
 	}
 
-	if traceID, ok := ctx.Value(TraceIDKey).(string); ok && traceID != "" {
+	// OpenTelemetryのSpanContextがあればそちらを優先し、なければ文字列キーにフォールバックする
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		logger = logger.With(
+			zap.String("trace_id", spanCtx.TraceID().String()),
+			zap.String("span_id", spanCtx.SpanID().String()),
+			zap.String("trace_flags", spanCtx.TraceFlags().String()),
+		)
+	} else if traceID, ok := ctx.Value(TraceIDKey).(string); ok && traceID != "" {
 		logger = logger.With(zap.String("trace_id", traceID))
 	}
 
@@ -97,84 +162,101 @@ func WithContext(ctx context.Context) *zap.Logger {
 }
 
 // Info はINFOレベルのログを出力する
-func Info(ctx context.Context, message string, fields ...zap.Field) {
-	WithContext(ctx).Info(message, fields...)
+func Info(ctx context.Context, message string, fields ...Field) {
+	WithContext(ctx).Info(message, toZapFields(fields)...)
 }
 
 // Debug はDEBUGレベルのログを出力する
-func Debug(ctx context.Context, message string, fields ...zap.Field) {
-	WithContext(ctx).Debug(message, fields...)
+func Debug(ctx context.Context, message string, fields ...Field) {
+	WithContext(ctx).Debug(message, toZapFields(fields)...)
 }
 
 // Warn はWARNレベルのログを出力する
-func Warn(ctx context.Context, message string, fields ...zap.Field) {
-	WithContext(ctx).Warn(message, fields...)
+func Warn(ctx context.Context, message string, fields ...Field) {
+	WithContext(ctx).Warn(message, toZapFields(fields)...)
 }
 
 // Error はERRORレベルのログを出力する
-func Error(ctx context.Context, message string, fields ...zap.Field) {
-	WithContext(ctx).Error(message, fields...)
+func Error(ctx context.Context, message string, fields ...Field) {
+	fields = append(fields, Any("stack", resolveStack(errFromFields(fields), 1)))
+	WithContext(ctx).Error(message, toZapFields(fields)...)
 }
 
-// LogDatabaseError はデータベースエラーをログ出力する
-func LogDatabaseError(ctx context.Context, operation, table string, err error, details ...map[string]interface{}) {
-	fields := []zap.Field{
-		zap.String("error_type", "database"),
-		zap.String("operation", operation),
-		zap.String("table", table),
-		zap.Error(err),
-	}
-
-	// detailsをzap.Fieldに変換
-	if len(details) > 0 {
-		for k, v := range details[0] {
-			fields = append(fields, zap.Any(k, v))
+// errFromFields はフィールド列から"error"キーのerror値を探す(あればWrapError由来のスタックを優先するため)
+func errFromFields(fields []Field) error {
+	for _, f := range fields {
+		if f.Key() == "error" {
+			if err, ok := f.Value().(error); ok {
+				return err
+			}
 		}
 	}
-
-	WithContext(ctx).Error("Database error occurred", fields...)
+	return nil
 }
 
-// LogBusinessError はビジネスロジックエラーをログ出力する
-func LogBusinessError(ctx context.Context, rule string, err error, details ...map[string]interface{}) {
-	fields := []zap.Field{
-		zap.String("error_type", "business"),
-		zap.String("rule", rule),
-		zap.Error(err),
+// Print は呼び出し側で動的に決まるレベルでログ出力するディスパッチャー
+func Print(ctx context.Context, level zapcore.Level, message string, err error, fields ...Field) {
+	if err != nil {
+		fields = append(fields, Err(err))
 	}
 
-	// detailsをzap.Fieldに変換
-	if len(details) > 0 {
-		for k, v := range details[0] {
-			fields = append(fields, zap.Any(k, v))
-		}
+	l := WithContext(ctx)
+	zapFields := toZapFields(fields)
+
+	switch level {
+	case zapcore.DebugLevel:
+		l.Debug(message, zapFields...)
+	case zapcore.WarnLevel:
+		l.Warn(message, zapFields...)
+	case zapcore.ErrorLevel:
+		l.Error(message, zapFields...)
+	default:
+		l.Info(message, zapFields...)
 	}
+}
 
-	WithContext(ctx).Warn("Business rule violation", fields...)
+// LogDatabaseError はデータベースエラーをログ出力する
+func LogDatabaseError(ctx context.Context, operation, table string, err error, fields ...Field) {
+	allFields := append([]Field{
+		String("error_type", "database"),
+		String("operation", operation),
+		String("table", table),
+		Err(err),
+		Any("stack", resolveStack(err, 1)),
+	}, fields...)
+
+	WithContext(ctx).Error("Database error occurred", toZapFields(allFields)...)
 }
 
-// LogValidationError はバリデーションエラーをログ出力する
-func LogValidationError(ctx context.Context, field string, value interface{}, reason string, details ...map[string]interface{}) {
-	fields := []zap.Field{
-		zap.String("error_type", "validation"),
-		zap.String("field", field),
-		zap.Any("value", value),
-		zap.String("reason", reason),
-	}
-
-	// detailsをzap.Fieldに変換
-	if len(details) > 0 {
-		for k, v := range details[0] {
-			fields = append(fields, zap.Any(k, v))
-		}
-	}
+// LogBusinessError はビジネスロジックエラーをログ出力する
+func LogBusinessError(ctx context.Context, rule string, err error, fields ...Field) {
+	allFields := append([]Field{
+		String("error_type", "business"),
+		String("rule", rule),
+		Err(err),
+	}, fields...)
+
+	WithContext(ctx).Warn("Business rule violation", toZapFields(allFields)...)
+}
 
-	WithContext(ctx).Warn("Validation error", fields...)
+// LogValidationError はバリデーションエラーをログ出力する
+func LogValidationError(ctx context.Context, field string, value interface{}, reason string, fields ...Field) {
+	allFields := append([]Field{
+		String("error_type", "validation"),
+		String("field", field),
+		Any("value", value),
+		String("reason", reason),
+	}, fields...)
+
+	WithContext(ctx).Warn("Validation error", toZapFields(allFields)...)
 }
 
-// TraceMethodAuto はメソッドの開始と終了を自動でログ出力する
-// 使用例: defer logger.TraceMethodAuto(ctx, params)()
-func TraceMethodAuto(ctx context.Context, params interface{}) func() {
+// tracer はTraceMethodAutoが使用するOpenTelemetryトレーサー
+var tracer = otel.Tracer("github.com/sake-kasu/sake-hack-backend")
+
+// TraceMethodAuto はメソッドの開始と終了を自動でログ出力し、呼び出し元関数名の子スパンを生成する
+// 使用例: ctx, done := logger.TraceMethodAuto(ctx, params); defer done()
+func TraceMethodAuto(ctx context.Context, params interface{}) (context.Context, func()) {
 	start := time.Now()
 
 	// 呼び出し元の関数名を取得
@@ -187,6 +269,8 @@ func TraceMethodAuto(ctx context.Context, params interface{}) func() {
 		}
 	}
 
+	ctx, span := tracer.Start(ctx, methodName)
+
 	logger := WithContext(ctx)
 
 	// メソッド開始ログ
@@ -195,7 +279,7 @@ func TraceMethodAuto(ctx context.Context, params interface{}) func() {
 		zap.String("phase", "start"),
 	)
 
-	return func() {
+	return ctx, func() {
 		// メソッド終了ログ
 		duration := time.Since(start)
 		logger.Debug("Method completed",
@@ -203,6 +287,8 @@ func TraceMethodAuto(ctx context.Context, params interface{}) func() {
 			zap.String("phase", "end"),
 			zap.Int64("duration_ms", duration.Milliseconds()),
 		)
+		metrics.ObserveRequestLatency(duration.Milliseconds())
+		span.End()
 	}
 }
 
@@ -0,0 +1,440 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// LokiConfig はLoki `/loki/api/v1/push` への送信設定
+type LokiConfig struct {
+	Enable        bool
+	Host          string
+	Port          int
+	Job           string
+	Service       string
+	Env           string
+	BatchSize     int
+	BatchInterval time.Duration
+	HTTPTimeout   time.Duration
+	MaxRetries    int
+	// DropDir はパイプライン飽和時/送信失敗時にログを退避するディレクトリ
+	DropDir string
+}
+
+// dynamicLabelKeys はコンテキストから動的ラベルとして引き継ぐフィールド名
+var dynamicLabelKeys = map[string]struct{}{
+	"request_id": {},
+	"trace_id":   {},
+	"user_id":    {},
+}
+
+// lokiEntry はバッチ送信待ちの1ログエントリ
+type lokiEntry struct {
+	tsNano int64
+	line   string
+	labels map[string]string
+}
+
+// lokiCore はLokiへログをフォワードするzapcore.Core実装
+type lokiCore struct {
+	level   zapcore.LevelEnabler
+	enc     zapcore.Encoder
+	batcher *lokiBatcher
+	labels  map[string]string
+	// dynamicLabels はlogger.With経由で積まれたフィールドのうちdynamicLabelKeysに
+	// 一致するものを保持する(エンコーダには積まれるがWrite時のfields引数には現れないため)
+	dynamicLabels map[string]string
+}
+
+// NewLokiCore はLoki送信用のzapcore.Coreを作成する
+func NewLokiCore(cfg LokiConfig, level zapcore.LevelEnabler) zapcore.Core {
+	encoderConfig := zapcore.EncoderConfig{
+		TimeKey:        "ts",
+		LevelKey:       "level",
+		MessageKey:     "msg",
+		NameKey:        "logger",
+		CallerKey:      "caller",
+		StacktraceKey:  "stack",
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeDuration: zapcore.MillisDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+
+	return &lokiCore{
+		level:   level,
+		enc:     zapcore.NewJSONEncoder(encoderConfig),
+		batcher: newLokiBatcher(cfg),
+		labels: map[string]string{
+			"job":     cfg.Job,
+			"service": cfg.Service,
+			"env":     cfg.Env,
+		},
+		dynamicLabels: map[string]string{},
+	}
+}
+
+func (c *lokiCore) Enabled(lvl zapcore.Level) bool {
+	return c.level.Enabled(lvl)
+}
+
+func (c *lokiCore) With(fields []zapcore.Field) zapcore.Core {
+	dynamicLabels := make(map[string]string, len(c.dynamicLabels))
+	for k, v := range c.dynamicLabels {
+		dynamicLabels[k] = v
+	}
+
+	clone := &lokiCore{
+		level:         c.level,
+		enc:           c.enc.Clone(),
+		batcher:       c.batcher,
+		labels:        c.labels,
+		dynamicLabels: dynamicLabels,
+	}
+	for _, f := range fields {
+		f.AddTo(clone.enc)
+		if _, ok := dynamicLabelKeys[f.Key]; ok {
+			if v, ok := dynamicLabelValue(f); ok {
+				clone.dynamicLabels[f.Key] = v
+			}
+		}
+	}
+	return clone
+}
+
+// dynamicLabelValue はzapcore.Fieldの型に応じて文字列値を取り出す。
+// request_id/trace_id/user_idはzap.String経由で渡される想定だが、念のためInterfaceにも
+// フォールバックし、それ以外の型はラベルとして扱わない
+func dynamicLabelValue(f zapcore.Field) (string, bool) {
+	switch f.Type {
+	case zapcore.StringType:
+		return f.String, f.String != ""
+	default:
+		if s, ok := f.Interface.(string); ok {
+			return s, s != ""
+		}
+		return "", false
+	}
+}
+
+func (c *lokiCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *lokiCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return fmt.Errorf("loki向けログエントリのエンコードに失敗しました: %w", err)
+	}
+	defer buf.Free()
+
+	labels := make(map[string]string, len(c.labels)+len(c.dynamicLabels)+len(dynamicLabelKeys)+1)
+	for k, v := range c.labels {
+		labels[k] = v
+	}
+	// logger.With経由(コンテキスト伝播)で積まれた動的ラベル
+	for k, v := range c.dynamicLabels {
+		labels[k] = v
+	}
+	labels["level"] = ent.Level.String()
+
+	// ログ呼び出しに直接渡されたフィールド由来の動的ラベル(With経由のものを上書き可能)
+	for _, f := range fields {
+		if _, ok := dynamicLabelKeys[f.Key]; ok {
+			if v, ok := dynamicLabelValue(f); ok {
+				labels[f.Key] = v
+			}
+		}
+	}
+
+	c.batcher.enqueue(lokiEntry{
+		tsNano: ent.Time.UnixNano(),
+		line:   buf.String(),
+		labels: labels,
+	})
+
+	return nil
+}
+
+func (c *lokiCore) Sync() error {
+	return c.batcher.flushNow()
+}
+
+// Close はバッチャーを停止し、残りのログを送信する
+func (c *lokiCore) Close() error {
+	return c.batcher.close()
+}
+
+// lokiBatcher は時間窓/最大サイズでログをバッチングし、Lokiへpushするワーカー
+type lokiBatcher struct {
+	cfg     LokiConfig
+	url     string
+	client  *http.Client
+	entries chan lokiEntry
+	flush   chan chan struct{}
+	done    chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	closed  bool
+}
+
+func newLokiBatcher(cfg LokiConfig) *lokiBatcher {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.BatchInterval <= 0 {
+		cfg.BatchInterval = 2 * time.Second
+	}
+	if cfg.HTTPTimeout <= 0 {
+		cfg.HTTPTimeout = 5 * time.Second
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = 3
+	}
+
+	b := &lokiBatcher{
+		cfg:     cfg,
+		url:     fmt.Sprintf("http://%s:%d/loki/api/v1/push", cfg.Host, cfg.Port),
+		client:  &http.Client{Timeout: cfg.HTTPTimeout},
+		entries: make(chan lokiEntry, cfg.BatchSize*10),
+		flush:   make(chan chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	b.wg.Add(1)
+	go b.run()
+
+	return b
+}
+
+// enqueue はログエントリをキューへ積む。飽和時はブロックせずディスクへ退避する
+func (b *lokiBatcher) enqueue(e lokiEntry) {
+	select {
+	case b.entries <- e:
+	default:
+		b.writeToDisk([]lokiEntry{e})
+	}
+}
+
+func (b *lokiBatcher) run() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.cfg.BatchInterval)
+	defer ticker.Stop()
+
+	buf := make([]lokiEntry, 0, b.cfg.BatchSize)
+
+	send := func() {
+		if len(buf) == 0 {
+			return
+		}
+		b.send(buf)
+		buf = buf[:0]
+	}
+
+	for {
+		select {
+		case e := <-b.entries:
+			buf = append(buf, e)
+			if len(buf) >= b.cfg.BatchSize {
+				send()
+			}
+		case <-ticker.C:
+			send()
+		case reply := <-b.flush:
+			send()
+			close(reply)
+		case <-b.done:
+			// キューに残っているものを吐き出してから終了する
+			for {
+				select {
+				case e := <-b.entries:
+					buf = append(buf, e)
+				default:
+					send()
+					return
+				}
+			}
+		}
+	}
+}
+
+// send はバッチをLokiへpushする。失敗時は指数バックオフで再試行し、最終的に失敗すればディスクへ退避する
+func (b *lokiBatcher) send(entries []lokiEntry) {
+	body, err := buildLokiPushBody(entries)
+	if err != nil {
+		b.writeToDisk(entries)
+		return
+	}
+
+	backoff := 200 * time.Millisecond
+	for attempt := 0; attempt <= b.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		if b.push(body) {
+			return
+		}
+	}
+
+	b.writeToDisk(entries)
+}
+
+func (b *lokiBatcher) push(body []byte) bool {
+	var gzBuf bytes.Buffer
+	gz := gzip.NewWriter(&gzBuf)
+	if _, err := gz.Write(body); err != nil {
+		return false
+	}
+	if err := gz.Close(); err != nil {
+		return false
+	}
+
+	req, err := http.NewRequest(http.MethodPost, b.url, &gzBuf)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		// 接続エラーはリトライ対象
+		return false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return false
+	}
+
+	// 4xxはリトライしても解決しないため、ここでは成功扱いとしてドロップする
+	return true
+}
+
+// writeToDisk は送信できなかったログをJSON Lines形式でディスクに退避する
+func (b *lokiBatcher) writeToDisk(entries []lokiEntry) {
+	if b.cfg.DropDir == "" {
+		return
+	}
+
+	if err := os.MkdirAll(b.cfg.DropDir, 0o755); err != nil {
+		return
+	}
+
+	path := filepath.Join(b.cfg.DropDir, fmt.Sprintf("loki-dropped-%s.jsonl", time.Now().UTC().Format("20060102")))
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	for _, e := range entries {
+		// lokiEntryのフィールドは非公開でjson.Marshalの対象にならないため、公開フィールドへ詰め替える
+		raw, err := json.Marshal(struct {
+			TsNano int64             `json:"ts_nano"`
+			Line   string            `json:"line"`
+			Labels map[string]string `json:"labels"`
+		}{e.tsNano, e.line, e.labels})
+		if err != nil {
+			continue
+		}
+		f.Write(raw)
+		f.Write([]byte("\n"))
+	}
+}
+
+// flushNow はキューにあるログを即座に送信する
+func (b *lokiBatcher) flushNow() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.mu.Unlock()
+
+	reply := make(chan struct{})
+	select {
+	case b.flush <- reply:
+		<-reply
+	case <-b.done:
+	}
+	return nil
+}
+
+func (b *lokiBatcher) close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	close(b.done)
+	b.wg.Wait()
+	return nil
+}
+
+// lokiStream はLoki push APIの1ストリーム分
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// lokiPushRequest はLoki push APIのリクエストボディ
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+// buildLokiPushBody はラベルの組み合わせごとにエントリをストリームへまとめる
+func buildLokiPushBody(entries []lokiEntry) ([]byte, error) {
+	streamsByKey := make(map[string]*lokiStream)
+	order := make([]string, 0, len(entries))
+
+	for _, e := range entries {
+		key := labelKey(e.labels)
+		stream, ok := streamsByKey[key]
+		if !ok {
+			stream = &lokiStream{Stream: e.labels}
+			streamsByKey[key] = stream
+			order = append(order, key)
+		}
+		stream.Values = append(stream.Values, [2]string{fmt.Sprintf("%d", e.tsNano), e.line})
+	}
+
+	req := lokiPushRequest{Streams: make([]lokiStream, 0, len(order))}
+	for _, key := range order {
+		req.Streams = append(req.Streams, *streamsByKey[key])
+	}
+
+	return json.Marshal(req)
+}
+
+// labelKey はラベル集合を比較可能な文字列へ変換する
+func labelKey(labels map[string]string) string {
+	var buf bytes.Buffer
+	for _, k := range []string{"job", "service", "env", "level", "request_id", "trace_id", "user_id"} {
+		if v, ok := labels[k]; ok {
+			buf.WriteString(k)
+			buf.WriteByte('=')
+			buf.WriteString(v)
+			buf.WriteByte(';')
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,126 @@
+package logger
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+)
+
+// StackFrame はスタックトレースの1フレーム
+type StackFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// stackConfig はスタックトレース採取の深さ/除外パターン。Initで上書きできる
+var stackConfig = struct {
+	depth        int
+	skipPatterns []string
+}{
+	depth: 32,
+	skipPatterns: []string{
+		"sake-hack-backend/internal/logger.",
+		"runtime.",
+	},
+}
+
+// ConfigureStackCapture はスタックトレース採取の深さと除外パターンを設定する
+func ConfigureStackCapture(depth int, skipPatterns []string) {
+	if depth > 0 {
+		stackConfig.depth = depth
+	}
+	if len(skipPatterns) > 0 {
+		stackConfig.skipPatterns = skipPatterns
+	}
+}
+
+// captureStack は現在の呼び出し位置からのスタックトレースを採取する
+// skip はruntime.Callers自身とcaptureStackを呼び出した直接のフレームをスキップする数
+func captureStack(skip int) []StackFrame {
+	pcs := make([]uintptr, stackConfig.depth)
+	n := runtime.Callers(skip+1, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	frameIter := runtime.CallersFrames(pcs[:n])
+	frames := make([]StackFrame, 0, n)
+
+	for {
+		frame, more := frameIter.Next()
+		if !shouldSkipFrame(frame.Function) {
+			frames = append(frames, StackFrame{
+				Function: frame.Function,
+				File:     frame.File,
+				Line:     frame.Line,
+			})
+		}
+		if !more {
+			break
+		}
+	}
+
+	return frames
+}
+
+func shouldSkipFrame(function string) bool {
+	for _, pattern := range stackConfig.skipPatterns {
+		if strings.Contains(function, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// stackCapturer はWrapErrorが生成したエラーが捕捉時点のスタックを保持するためのインターフェース
+type stackCapturer interface {
+	Stack() []StackFrame
+}
+
+// wrappedError はWrapErrorで捕捉地点のスタックトレースを付与したエラー
+type wrappedError struct {
+	err   error
+	stack []StackFrame
+}
+
+func (w *wrappedError) Error() string {
+	return w.err.Error()
+}
+
+func (w *wrappedError) Unwrap() error {
+	return w.err
+}
+
+func (w *wrappedError) Stack() []StackFrame {
+	return w.stack
+}
+
+// WrapError はエラーに捕捉地点のスタックトレースを付与する
+// 複数層を越えてエラーが伝播しても、最初に捕捉した地点のスタックがログに残る
+func WrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &wrappedError{err: err, stack: captureStack(2)}
+}
+
+// stackFromError はエラーチェーンからWrapErrorが付与した捕捉地点のスタックを取り出す
+// 見つからない場合はnilを返す
+func stackFromError(err error) []StackFrame {
+	var capturer stackCapturer
+	if errors.As(err, &capturer) {
+		return capturer.Stack()
+	}
+	return nil
+}
+
+// resolveStack はエラーに捕捉地点のスタックがあればそれを、なければ現在位置のスタックを返す
+func resolveStack(err error, skip int) []StackFrame {
+	if err != nil {
+		if frames := stackFromError(err); frames != nil {
+			return frames
+		}
+	}
+	return captureStack(skip + 1)
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import "go.uber.org/zap/zapcore"
+
+// hookedCore はzapcore.Coreをラップし、Write時にログレベル別/エラー種別別カウンタを更新する
+type hookedCore struct {
+	zapcore.Core
+}
+
+// WrapCore はログ出力をexpvarカウンタに反映するフックを追加する
+func WrapCore(core zapcore.Core) zapcore.Core {
+	return &hookedCore{Core: core}
+}
+
+func (c *hookedCore) With(fields []zapcore.Field) zapcore.Core {
+	return &hookedCore{Core: c.Core.With(fields)}
+}
+
+func (c *hookedCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *hookedCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	IncLogLevel(ent.Level.String())
+
+	for _, f := range fields {
+		if f.Key == "error_type" && f.Type == zapcore.StringType {
+			IncErrorType(f.String)
+		}
+	}
+
+	return c.Core.Write(ent, fields)
+}
@@ -0,0 +1,12 @@
+package metrics
+
+import (
+	"expvar"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes は /debug/vars にexpvarのハンドラをマウントする
+func RegisterRoutes(router *gin.Engine) {
+	router.GET("/debug/vars", gin.WrapH(expvar.Handler()))
+}
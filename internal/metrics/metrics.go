@@ -0,0 +1,138 @@
+// Package metrics はexpvarベースのランタイムメトリクスを公開する
+package metrics
+
+import (
+	"expvar"
+	"sync"
+)
+
+var (
+	// LogCounts はログレベル別の出力件数。expvarのlog_level_countマップにレベル名(info, error, ...)をキーとして記録する。
+	// log_info_countのようなレベルごとの個別変数は公開しない
+	LogCounts = expvar.NewMap("log_level_count")
+
+	// ErrorTypeCounts はLogDatabaseError/LogBusinessError/LogValidationError由来のエラー種別件数
+	ErrorTypeCounts = expvar.NewMap("error_type_count")
+
+	// RequestCount はTraceMethodAutoで計測された呼び出し総数
+	RequestCount = expvar.NewInt("request_count")
+
+	// RequestLatencyMsBuckets はメソッド呼び出しのレイテンシをバケット化したヒストグラム
+	RequestLatencyMsBuckets = expvar.NewMap("request_latency_ms_buckets")
+
+	// CacheCounts はキャッシュのhit/miss/singleflight_shared/error件数 (cachedSakeRepository由来)
+	CacheCounts = expvar.NewMap("cache_count")
+
+	// RateLimitCounts はレートリミッターのallowed/limited/error件数 (internal/middleware由来)
+	RateLimitCounts = expvar.NewMap("rate_limit_count")
+
+	publishMu       sync.Mutex
+	publishedGauges = map[string]struct{}{}
+)
+
+// latencyBuckets はヒストグラムの境界値(ミリ秒)
+var latencyBuckets = []int64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000}
+
+// IncLogLevel はログレベル別カウンタをインクリメントする
+func IncLogLevel(level string) {
+	LogCounts.Add(level, 1)
+}
+
+// IncErrorType はエラー種別カウンタをインクリメントする
+func IncErrorType(errorType string) {
+	ErrorTypeCounts.Add(errorType, 1)
+}
+
+// ObserveRequestLatency はメソッド呼び出しのレイテンシをヒストグラムへ記録する
+func ObserveRequestLatency(durationMs int64) {
+	RequestCount.Add(1)
+	RequestLatencyMsBuckets.Add(bucketLabel(durationMs), 1)
+}
+
+// bucketLabel はレイテンシ値が収まる最小のバケット境界のラベルを返す
+func bucketLabel(durationMs int64) string {
+	for _, b := range latencyBuckets {
+		if durationMs <= b {
+			return bucketName(b)
+		}
+	}
+	return "+Inf"
+}
+
+func bucketName(b int64) string {
+	switch b {
+	case 5:
+		return "5ms"
+	case 10:
+		return "10ms"
+	case 25:
+		return "25ms"
+	case 50:
+		return "50ms"
+	case 100:
+		return "100ms"
+	case 250:
+		return "250ms"
+	case 500:
+		return "500ms"
+	case 1000:
+		return "1s"
+	case 2500:
+		return "2.5s"
+	case 5000:
+		return "5s"
+	default:
+		return "+Inf"
+	}
+}
+
+// IncCacheHit はキャッシュヒット件数をインクリメントする
+func IncCacheHit() {
+	CacheCounts.Add("hit", 1)
+}
+
+// IncCacheMiss はキャッシュミス件数をインクリメントする
+func IncCacheMiss() {
+	CacheCounts.Add("miss", 1)
+}
+
+// IncCacheSingleflightShared はsingleflightによりDB呼び出しを共有した件数をインクリメントする
+func IncCacheSingleflightShared() {
+	CacheCounts.Add("singleflight_shared", 1)
+}
+
+// IncCacheError はキャッシュの取得/保存エラー件数をインクリメントする
+func IncCacheError() {
+	CacheCounts.Add("error", 1)
+}
+
+// IncRateLimitAllowed はレートリミッターを通過したリクエスト件数をインクリメントする
+func IncRateLimitAllowed() {
+	RateLimitCounts.Add("allowed", 1)
+}
+
+// IncRateLimitLimited はレートリミッターに拒否された(429)リクエスト件数をインクリメントする
+func IncRateLimitLimited() {
+	RateLimitCounts.Add("limited", 1)
+}
+
+// IncRateLimitError はレートリミッターのValkey呼び出しエラー件数をインクリメントする
+func IncRateLimitError() {
+	RateLimitCounts.Add("error", 1)
+}
+
+// Publish は任意のパッケージが自前のゲージ/ステータスを /debug/vars へ公開するためのヘルパー
+// 同名のキーは一度しか登録できない(expvar.Publishはpanicするため)
+func Publish(name string, fn func() any) {
+	publishMu.Lock()
+	defer publishMu.Unlock()
+
+	if _, ok := publishedGauges[name]; ok {
+		return
+	}
+	publishedGauges[name] = struct{}{}
+
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return fn()
+	}))
+}
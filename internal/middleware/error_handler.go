@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sake-kasu/sake-hack-backend/internal/apperror"
+	"github.com/sake-kasu/sake-hack-backend/internal/logger"
+)
+
+// problemDetail はRFC 7807 (problem+json) に沿ったエラーレスポンスボディ
+type problemDetail struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail"`
+	Instance  string                 `json:"instance"`
+	Code      string                 `json:"code,omitempty"`
+	RequestID string                 `json:"requestId,omitempty"`
+	Fields    map[string]string      `json:"fields,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// ErrorHandler はハンドラ・他ミドルウェアで発生したエラー・パニックをproblem+jsonレスポンスに変換する
+// 各ハンドラで個別にc.JSONを呼ぶ代わりに、c.Error(err)で登録しておけばここで一括変換される。
+// mode には*config.Config.Server.Modeをそのまま渡す(gin.ReleaseModeの場合のみ内部エラーの詳細を隠す)
+func ErrorHandler(mode string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				err := fmt.Errorf("panic: %v", r)
+				logger.Error(c.Request.Context(), "ハンドラでパニックが発生しました", logger.Err(err))
+				writeProblem(c, mode, apperror.InternalServerError("内部エラーが発生しました").WithErr(err))
+			}
+		}()
+
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		logger.Error(c.Request.Context(), "リクエスト処理中にエラーが発生しました", logger.Err(err))
+		writeProblem(c, mode, err)
+	}
+}
+
+// writeProblem はerrをRFC 7807形式に変換してレスポンスへ書き込む
+func writeProblem(c *gin.Context, mode string, err error) {
+	requestID, _ := c.Request.Context().Value(logger.RequestIDKey).(string)
+
+	var valErr *apperror.ValidationError
+	if errors.As(err, &valErr) {
+		c.Header("Content-Type", "application/problem+json")
+		c.AbortWithStatusJSON(valErr.Status, problemDetail{
+			Type:      "about:blank",
+			Title:     http.StatusText(valErr.Status),
+			Status:    valErr.Status,
+			Detail:    valErr.Message,
+			Instance:  c.Request.URL.Path,
+			Code:      valErr.Code,
+			RequestID: requestID,
+			Fields:    valErr.Fields,
+			Details:   valErr.Details,
+		})
+		return
+	}
+
+	if appErr := apperror.As(err); appErr != nil {
+		c.Header("Content-Type", "application/problem+json")
+		c.AbortWithStatusJSON(appErr.Status, problemDetail{
+			Type:      "about:blank",
+			Title:     http.StatusText(appErr.Status),
+			Status:    appErr.Status,
+			Detail:    appErr.Message,
+			Instance:  c.Request.URL.Path,
+			Code:      appErr.Code,
+			RequestID: requestID,
+			Details:   appErr.Details,
+		})
+		return
+	}
+
+	// AppErrorでない予期しないエラーはproductionモードではerr.Error()を含めない
+	detail := "内部エラーが発生しました"
+	if mode != gin.ReleaseMode {
+		detail = err.Error()
+	}
+
+	c.Header("Content-Type", "application/problem+json")
+	c.AbortWithStatusJSON(http.StatusInternalServerError, problemDetail{
+		Type:      "about:blank",
+		Title:     http.StatusText(http.StatusInternalServerError),
+		Status:    http.StatusInternalServerError,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		Code:      apperror.ErrCodeInternalError,
+		RequestID: requestID,
+	})
+}
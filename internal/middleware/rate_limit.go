@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sake-kasu/sake-hack-backend/internal/logger"
+	"github.com/sake-kasu/sake-hack-backend/internal/metrics"
+	"github.com/valkey-io/valkey-go"
+)
+
+// rateLimitKeyPrefix はレートリミッターが使うValkeyキーの名前空間
+const rateLimitKeyPrefix = "sake_rate_limit:"
+
+// slidingWindowScript はスライディングウィンドウカウンタアルゴリズムを実装するValkey Luaスクリプト
+// KEYS[1]=現在のウィンドウのキー KEYS[2]=直前のウィンドウのキー
+// ARGV[1]=直前ウィンドウの重み(1 - elapsed/window) ARGV[2]=上限 ARGV[3]=キーのTTL(ミリ秒)
+// 戻り値: {許可されたか(1/0), 加重カウント, 現在ウィンドウの生カウント}
+var slidingWindowScript = valkey.NewLuaScript(`
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local previous = tonumber(redis.call('GET', KEYS[2]) or '0')
+local weight = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local weighted = current + previous * weight
+if weighted >= limit then
+	return {0, weighted, current}
+end
+current = redis.call('INCR', KEYS[1])
+if current == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[3])
+end
+weighted = current + previous * weight
+return {1, weighted, current}
+`)
+
+// KeyFunc はレートリミットの対象を識別するキーをリクエストから導出する
+type KeyFunc func(c *gin.Context) string
+
+// RateLimitConfig はRateLimit()ミドルウェアの設定
+type RateLimitConfig struct {
+	Client valkey.Client
+	Limit  int           // ウィンドウあたりの許容リクエスト数
+	Window time.Duration // ウィンドウ長
+	// KeyFunc はリクエストごとのレートリミットキーを決定する。未設定の場合はクライアントIPを使う
+	KeyFunc KeyFunc
+}
+
+// DefaultKeyFunc はc.ClientIP()(X-Forwarded-For / RemoteAddrを考慮したGinの標準ロジック)をキーとする
+func DefaultKeyFunc(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// RateLimit はValkeyのスライディングウィンドウカウンタでクライアントごとのリクエスト数を制限するミドルウェア
+// 複数のバックエンドレプリカにまたがってValkey上の状態を共有するため、分散環境でも一貫したレート制限を行える
+func RateLimit(cfg RateLimitConfig) gin.HandlerFunc {
+	keyFunc := cfg.KeyFunc
+	if keyFunc == nil {
+		keyFunc = DefaultKeyFunc
+	}
+
+	windowMs := cfg.Window.Milliseconds()
+
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		now := time.Now()
+		currentBucket := now.UnixMilli() / windowMs
+		previousBucket := currentBucket - 1
+		elapsedMs := now.UnixMilli() - currentBucket*windowMs
+		weight := 1 - float64(elapsedMs)/float64(windowMs)
+
+		key := keyFunc(c)
+		currentKey := fmt.Sprintf("%s%s:%d", rateLimitKeyPrefix, key, currentBucket)
+		previousKey := fmt.Sprintf("%s%s:%d", rateLimitKeyPrefix, key, previousBucket)
+
+		resp := slidingWindowScript.Exec(ctx, cfg.Client,
+			[]string{currentKey, previousKey},
+			[]string{
+				strconv.FormatFloat(weight, 'f', -1, 64),
+				strconv.Itoa(cfg.Limit),
+				strconv.FormatInt(cfg.Window.Milliseconds()*2, 10),
+			},
+		)
+		if resp.Error() != nil {
+			// Valkeyに到達できない場合はリクエストを遮断せず通過させる(可用性を優先)
+			logger.Warn(ctx, "レートリミッターのValkey呼び出しに失敗しました", logger.String("key", key), logger.Err(resp.Error()))
+			metrics.IncRateLimitError()
+			c.Next()
+			return
+		}
+
+		result, err := resp.ToArray()
+		if err != nil || len(result) != 3 {
+			logger.Warn(ctx, "レートリミッターの応答解析に失敗しました", logger.String("key", key), logger.Err(err))
+			metrics.IncRateLimitError()
+			c.Next()
+			return
+		}
+
+		allowed, _ := result[0].ToInt64()
+		weighted, _ := result[1].ToFloat64()
+
+		remaining := cfg.Limit - int(weighted)
+		if remaining < 0 {
+			remaining = 0
+		}
+		resetSeconds := int64((float64(windowMs) - float64(elapsedMs)) / 1000)
+		if resetSeconds < 0 {
+			resetSeconds = 0
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(cfg.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(resetSeconds, 10))
+
+		if allowed == 0 {
+			metrics.IncRateLimitLimited()
+			c.Header("Retry-After", strconv.FormatInt(resetSeconds, 10))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"code":    "RATE_LIMIT_EXCEEDED",
+				"message": "リクエスト数が上限に達しました。しばらく待ってから再試行してください",
+			})
+			return
+		}
+
+		metrics.IncRateLimitAllowed()
+		c.Next()
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer はGinミドルウェアが使用するOpenTelemetryトレーサー
+var tracer = otel.Tracer("github.com/sake-kasu/sake-hack-backend/internal/middleware")
+
+// Tracing はリクエストごとにサーバースパンを開始するミドルウェア
+// 受信したtraceparentヘッダーがあれば継承し、レスポンスヘッダーにtrace idを書き戻す
+func Tracing() gin.HandlerFunc {
+	propagator := propagation.TraceContext{}
+
+	return func(c *gin.Context) {
+		ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		spanName := c.FullPath()
+		if spanName == "" {
+			spanName = c.Request.URL.Path
+		}
+
+		ctx, span := tracer.Start(ctx, spanName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.method", c.Request.Method),
+				attribute.String("http.route", spanName),
+			),
+		)
+		defer span.End()
+
+		c.Header("X-Trace-ID", span.SpanContext().TraceID().String())
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.status_code", status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, "internal server error")
+		}
+	}
+}
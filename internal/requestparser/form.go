@@ -0,0 +1,28 @@
+package requestparser
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// FormParser はapplication/x-www-form-urlencoded向けのParser実装
+// レガシーシステムやWebhookゲートウェイなどJSONを話さない連携先からの入力を受けるために用意する
+type FormParser struct{}
+
+// NewFormParser コンストラクタ
+func NewFormParser() *FormParser {
+	return &FormParser{}
+}
+
+// ExtractInput はapplication/x-www-form-urlencodedのボディをtargetへデコードする
+func (p *FormParser) ExtractInput(c *gin.Context, target any) error {
+	return c.ShouldBindWith(target, binding.Form)
+}
+
+// WriteOutput はpayloadを書き込む。
+// フォームエンコーディングはネストした構造を表現できないため、レスポンスは常にJSONで返す
+// (パートナー側もレスポンスはJSONとして解釈できることを前提としている)
+func (p *FormParser) WriteOutput(c *gin.Context, status int, payload any) error {
+	c.JSON(status, payload)
+	return nil
+}
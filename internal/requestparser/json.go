@@ -0,0 +1,22 @@
+package requestparser
+
+import "github.com/gin-gonic/gin"
+
+// JSONParser はapplication/json向けのデフォルトParser実装
+type JSONParser struct{}
+
+// NewJSONParser コンストラクタ
+func NewJSONParser() *JSONParser {
+	return &JSONParser{}
+}
+
+// ExtractInput はリクエストボディをJSONとしてtargetへデコードする
+func (p *JSONParser) ExtractInput(c *gin.Context, target any) error {
+	return c.ShouldBindJSON(target)
+}
+
+// WriteOutput はpayloadをJSONとして書き込む
+func (p *JSONParser) WriteOutput(c *gin.Context, status int, payload any) error {
+	c.JSON(status, payload)
+	return nil
+}
@@ -0,0 +1,57 @@
+package requestparser
+
+import (
+	"mime"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Registry はContent-TypeからParserを解決する
+type Registry struct {
+	parsers  map[string]Parser
+	byType   map[string]string // content-type -> parser名
+	fallback string
+}
+
+// NewRegistry はparsers(parser名 -> 実装)、byContentType(content-type -> parser名)、
+// fallback(未知のContent-Typeや未指定時に使うparser名)からRegistryを構築する
+func NewRegistry(parsers map[string]Parser, byContentType map[string]string, fallback string) *Registry {
+	return &Registry{
+		parsers:  parsers,
+		byType:   byContentType,
+		fallback: fallback,
+	}
+}
+
+// NewDefaultRegistry はJSON/フォームの標準構成でRegistryを構築する
+// config.RequestParserConfigで上書きしない限りこの構成が使われる
+func NewDefaultRegistry() *Registry {
+	return NewRegistry(
+		map[string]Parser{
+			"json": NewJSONParser(),
+			"form": NewFormParser(),
+		},
+		map[string]string{
+			"application/json":                  "json",
+			"application/x-www-form-urlencoded": "form",
+		},
+		"json",
+	)
+}
+
+// ForRequest はc.Request.Header.Get("Content-Type")を基にParserを選択する
+// 該当がなければfallbackのParserを返す
+func (r *Registry) ForRequest(c *gin.Context) Parser {
+	contentType := c.GetHeader("Content-Type")
+	if contentType != "" {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+			if name, ok := r.byType[mediaType]; ok {
+				if p, ok := r.parsers[name]; ok {
+					return p
+				}
+			}
+		}
+	}
+
+	return r.parsers[r.fallback]
+}
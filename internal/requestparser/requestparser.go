@@ -0,0 +1,14 @@
+// Package requestparser はプレゼンテーション層がトランスポート形式(JSON、フォームなど)に依存せず
+// 入出力を扱えるようにするための抽象化を提供する
+package requestparser
+
+import "github.com/gin-gonic/gin"
+
+// Parser はリクエストボディの展開とレスポンスの書き込みを抽象化するインターフェース
+// ハンドラはc.ShouldBindJSON/c.JSONを直接呼ぶ代わりにこのインターフェース経由で入出力を行う
+type Parser interface {
+	// ExtractInput はリクエストをtargetにデコードする(targetは構造体へのポインタ)
+	ExtractInput(c *gin.Context, target any) error
+	// WriteOutput はstatusとpayloadをレスポンスとして書き込む
+	WriteOutput(c *gin.Context, status int, payload any) error
+}
@@ -8,14 +8,18 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"github.com/sake-kasu/sake-hack-backend/api/generated"
+	"github.com/sake-kasu/sake-hack-backend/internal/cache"
 	"github.com/sake-kasu/sake-hack-backend/internal/config"
 	"github.com/sake-kasu/sake-hack-backend/internal/database"
 	sakeUsecase "github.com/sake-kasu/sake-hack-backend/internal/features/sake/application/usecase"
 	sakeRepository "github.com/sake-kasu/sake-hack-backend/internal/features/sake/infrastructure/repository"
 	sakePresentation "github.com/sake-kasu/sake-hack-backend/internal/features/sake/presentation"
 	"github.com/sake-kasu/sake-hack-backend/internal/logger"
+	"github.com/sake-kasu/sake-hack-backend/internal/metrics"
 	"github.com/sake-kasu/sake-hack-backend/internal/middleware"
+	"github.com/sake-kasu/sake-hack-backend/internal/requestparser"
 	"github.com/valkey-io/valkey-go"
 	"go.uber.org/zap"
 )
@@ -26,14 +30,16 @@ type Server struct {
 	httpServer   *http.Server
 	postgresPool *pgxpool.Pool
 	valkeyClient valkey.Client
+	redisClient  *redis.Client
 	config       *config.Config
 }
 
-// New は新しいサーバーを作成する
+// New は新しいサーバーを作成する。redisClientはcfg.Cache.Backend="redis"の場合のみ使用され、それ以外ではnilで良い
 func New(
 	cfg *config.Config,
 	postgresPool *pgxpool.Pool,
 	valkeyClient valkey.Client,
+	redisClient *redis.Client,
 ) *Server {
 	// Ginモード設定
 	gin.SetMode(cfg.Server.Mode)
@@ -42,8 +48,9 @@ func New(
 	router := gin.New()
 
 	// グローバルミドルウェア設定
-	router.Use(gin.Recovery())
 	router.Use(middleware.RequestID())
+	router.Use(middleware.ErrorHandler(cfg.Server.Mode))
+	router.Use(middleware.Tracing())
 	router.Use(middleware.CORS(middleware.CORSConfig{
 		AllowedOrigins:   cfg.CORS.AllowedOrigins,
 		AllowedMethods:   cfg.CORS.AllowedMethods,
@@ -52,12 +59,20 @@ func New(
 		AllowCredentials: cfg.CORS.AllowCredentials,
 		MaxAge:           cfg.CORS.MaxAge,
 	}))
+	if cfg.RateLimit.Enable && valkeyClient != nil {
+		router.Use(middleware.RateLimit(middleware.RateLimitConfig{
+			Client: valkeyClient,
+			Limit:  cfg.RateLimit.Limit,
+			Window: cfg.RateLimit.Window,
+		}))
+	}
 
 	// サーバーインスタンス作成
 	server := &Server{
 		router:       router,
 		postgresPool: postgresPool,
 		valkeyClient: valkeyClient,
+		redisClient:  redisClient,
 		config:       cfg,
 	}
 
@@ -111,6 +126,11 @@ func (s *Server) Shutdown() error {
 		s.valkeyClient.Close()
 	}
 
+	// Redisクライアントクローズ
+	if s.redisClient != nil {
+		s.redisClient.Close()
+	}
+
 	logger.Get().Info("サーバーを正常にシャットダウンしました")
 	return nil
 }
@@ -126,6 +146,7 @@ type HealthCheckResponse struct {
 type HealthCheckDatabaseResponse struct {
 	Postgres string `json:"postgres,omitempty"`
 	Valkey   string `json:"valkey,omitempty"`
+	Redis    string `json:"redis,omitempty"`
 }
 
 // setupRoutes はルートを設定する
@@ -133,19 +154,107 @@ func (s *Server) setupRoutes() {
 	// ヘルスチェックエンドポイント
 	s.router.GET("/health", s.handleHealth)
 
+	// expvarメトリクスエンドポイント
+	metrics.RegisterRoutes(s.router)
+	s.registerConnectionStatusGauges()
+
 	// Repository
 	sakeRepo := sakeRepository.NewSakeRepository(s.postgresPool)
+	if cacheBackend := s.newSakeCache(); cacheBackend != nil {
+		sakeRepo = sakeRepository.NewCachedSakeRepository(sakeRepo, cacheBackend, s.config.Cache.TTL)
+	}
 
 	// Usecase
 	listSakesUC := sakeUsecase.NewListSakesUsecase(sakeRepo)
 
 	// Presentation
-	sakeServer := sakePresentation.NewSakeServerImpl(listSakesUC)
+	sakeServer := sakePresentation.NewSakeServerImpl(listSakesUC, s.newRequestParserRegistry())
 
 	// OpenAPI ServerInterfaceをGinに登録
 	generated.RegisterHandlers(s.router, sakeServer)
 }
 
+// newSakeCache はcfg.Cache.Backendに応じたCache実装を構築する
+// 構築に失敗した場合(backend不明、valkey/redis未接続など)はキャッシュを使わずnilを返す
+func (s *Server) newSakeCache() cache.Cache {
+	switch s.config.Cache.Backend {
+	case "valkey":
+		if s.valkeyClient == nil {
+			logger.Get().Warn("cache.backend=valkeyが指定されましたがValkeyクライアントが未接続のためキャッシュを無効化します")
+			return nil
+		}
+		return cache.NewValkeyCache(s.valkeyClient)
+	case "redis":
+		if s.redisClient == nil {
+			logger.Get().Warn("cache.backend=redisが指定されましたがRedisクライアントが未接続のためキャッシュを無効化します")
+			return nil
+		}
+		return cache.NewRedisCache(s.redisClient)
+	case "lru":
+		lruCache, err := cache.NewLRUCache(s.config.Cache.LRUSize)
+		if err != nil {
+			logger.Get().Warn("LRUキャッシュの作成に失敗したためキャッシュを無効化します", zap.Error(err))
+			return nil
+		}
+		return lruCache
+	default:
+		logger.Get().Warn("未知のcache.backendが指定されたためキャッシュを無効化します", zap.String("backend", s.config.Cache.Backend))
+		return nil
+	}
+}
+
+// newRequestParserRegistry はcfg.RequestParserのContent-Type対応表からrequestparser.Registryを構築する
+func (s *Server) newRequestParserRegistry() *requestparser.Registry {
+	contentTypeMap := s.config.RequestParser.ContentTypeMap
+	if len(contentTypeMap) == 0 {
+		contentTypeMap = map[string]string{
+			"application/json":                  "json",
+			"application/x-www-form-urlencoded": "form",
+		}
+	}
+
+	return requestparser.NewRegistry(
+		map[string]requestparser.Parser{
+			"json": requestparser.NewJSONParser(),
+			"form": requestparser.NewFormParser(),
+		},
+		contentTypeMap,
+		s.config.RequestParser.Default,
+	)
+}
+
+// registerConnectionStatusGauges はDBの死活状態をexpvarのconnection_statusとして公開する
+func (s *Server) registerConnectionStatusGauges() {
+	metrics.Publish("connection_status", func() any {
+		status := map[string]any{}
+
+		if s.postgresPool != nil {
+			err := database.HealthCheckPostgresPool(context.Background(), s.postgresPool)
+			status["postgres"] = connectionStatusEntry(err)
+		}
+
+		if s.valkeyClient != nil {
+			err := database.HealthCheckValkey(context.Background(), s.valkeyClient)
+			status["valkey"] = connectionStatusEntry(err)
+		}
+
+		if s.redisClient != nil {
+			err := database.HealthCheckRedis(context.Background(), s.redisClient)
+			status["redis"] = connectionStatusEntry(err)
+		}
+
+		return status
+	})
+}
+
+// connectionStatusEntry はヘルスチェック結果を公開用の構造へ変換する
+func connectionStatusEntry(err error) map[string]any {
+	if err != nil {
+		return map[string]any{"up": false, "last_error": err.Error()}
+	}
+	return map[string]any{"up": true, "last_error": nil}
+}
+
 // handleHealth はヘルスチェックハンドラ
 func (s *Server) handleHealth(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -176,6 +285,16 @@ func (s *Server) handleHealth(c *gin.Context) {
 		}
 	}
 
+	// Redisヘルスチェック
+	if s.redisClient != nil {
+		if err := database.HealthCheckRedis(ctx, s.redisClient); err != nil {
+			response.Database.Redis = "error"
+			response.Status = "degraded"
+		} else {
+			response.Database.Redis = "ok"
+		}
+	}
+
 	statusCode := http.StatusOK
 	if response.Status != "ok" {
 		statusCode = http.StatusServiceUnavailable